@@ -0,0 +1,95 @@
+package lardiAPI
+
+import (
+	"context"
+	"fmt"
+)
+
+// Account describes the user the API key belongs to
+type Account struct {
+	ID          int            `json:"id"`
+	Name        string         `json:"name"`
+	Plan        string         `json:"plan"`
+	Permissions []string       `json:"permissions"`
+	Limits      map[string]int `json:"limits"`
+	// Usage holds the current value of each limit
+	Usage map[string]int `json:"usage"`
+}
+
+// limitActiveCargos is the key of the active proposals quota in
+// Account.Limits and Account.Usage
+const limitActiveCargos = "activeCargos"
+
+// CargoLimits describes the quota on active cargo proposals
+type CargoLimits struct {
+	// MaxActive is the number of proposals that may be active at once,
+	// zero when the account has no limit
+	MaxActive int
+	// Active is the number of currently active proposals
+	Active int
+}
+
+// Remaining returns how many more proposals can be activated, or -1 when
+// the account has no limit
+func (l *CargoLimits) Remaining() int {
+	if l.MaxActive == 0 {
+		return -1
+	}
+	return max(l.MaxActive-l.Active, 0)
+}
+
+// HasPermission reports whether the account has the named permission
+func (a *Account) HasPermission(name string) bool {
+	for _, p := range a.Permissions {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAccountInfo retrieves the plan, permissions and limits of the account
+// the API key belongs to
+func (c *Client) GetAccountInfo(ctx context.Context, opts ...RequestOption) (*Account, error) {
+	var resp Account
+	err := c.get(ctx, pathUser, &resp, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("get account info failed: %w", err)
+	}
+	return &resp, nil
+}
+
+// GetBranches retrieves the branches of the account that cargo proposals
+// can be posted under with CargoRequest.BranchID
+func (c *Client) GetBranches(ctx context.Context, opts ...RequestOption) ([]Response, error) {
+	var resp []Response
+	err := c.get(ctx, pathBranches, &resp, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("get branches failed: %w", err)
+	}
+	return resp, nil
+}
+
+// withDefaults returns req with Config.DefaultBranchID applied. req itself
+// is not modified.
+func (c *Client) withDefaults(req *CargoRequest) *CargoRequest {
+	if req.BranchID != 0 || c.config.DefaultBranchID == 0 {
+		return req
+	}
+	r := *req
+	r.BranchID = c.config.DefaultBranchID
+	return &r
+}
+
+// GetCargoLimits retrieves the account's quota on active cargo proposals and
+// its current usage. It shares the endpoint of GetAccountInfo.
+func (c *Client) GetCargoLimits(ctx context.Context, opts ...RequestOption) (*CargoLimits, error) {
+	account, err := c.GetAccountInfo(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &CargoLimits{
+		MaxActive: account.Limits[limitActiveCargos],
+		Active:    account.Usage[limitActiveCargos],
+	}, nil
+}
@@ -0,0 +1,66 @@
+package lardiAPI
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// parseAmount parses a formatted monetary amount such as "12 500 грн",
+// "1,250.50", "1.250,50 руб." or "$1500". Currency symbols, letters, spaces
+// and '.' or ',' not between two digits are ignored. When both '.' and ',' occur, the last one is the decimal
+// separator; a single separator followed by exactly three digits, as in
+// "1,500", separates thousands, so "0.500" is 500 while "1,5" is 1.5.
+func parseAmount(s string) (float64, error) {
+	isDigit := func(r rune) bool { return r >= '0' && r <= '9' }
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == '.' || r == ',':
+			// Only separators between digits belong to the number; others
+			// are punctuation such as the period of "грн."
+			if i > 0 && i < len(runes)-1 && isDigit(runes[i-1]) && isDigit(runes[i+1]) {
+				b.WriteRune(r)
+			}
+		case isDigit(r), r == '-':
+			b.WriteRune(r)
+		case unicode.IsSpace(r), unicode.IsLetter(r), unicode.Is(unicode.Sc, r), r == '\'':
+		default:
+			return 0, fmt.Errorf("invalid amount %q", s)
+		}
+	}
+	digits := b.String()
+	if digits == "" {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+
+	decimal := byte(0)
+	lastDot, lastComma := strings.LastIndexByte(digits, '.'), strings.LastIndexByte(digits, ',')
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		decimal = digits[max(lastDot, lastComma)]
+	case lastDot >= 0 || lastComma >= 0:
+		sep := digits[max(lastDot, lastComma)]
+		if strings.Count(digits, string(sep)) == 1 && len(digits)-max(lastDot, lastComma)-1 != 3 {
+			decimal = sep
+		}
+	}
+
+	var normalized strings.Builder
+	for i := 0; i < len(digits); i++ {
+		switch c := digits[i]; {
+		case c == decimal:
+			normalized.WriteByte('.')
+		case c == '.' || c == ',':
+		default:
+			normalized.WriteByte(c)
+		}
+	}
+	v, err := strconv.ParseFloat(normalized.String(), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	return v, nil
+}
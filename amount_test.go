@@ -0,0 +1,82 @@
+package lardiAPI
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"12 500 грн", 12500},
+		{"1,250.50", 1250.50},
+		{"1.250,50", 1250.50},
+		{"$1500", 1500},
+		{"1,500", 1500},
+		{"1'500 EUR", 1500},
+		{"1,5", 1.5},
+		{"12.75", 12.75},
+		// A single separator before three digits separates thousands
+		{"0.500", 500},
+		{"-300", -300},
+		// The period of an abbreviated currency is not a separator
+		{"1 250,50 грн.", 1250.50},
+		{"1,250.50 грн.", 1250.50},
+		{"1.250,50 руб.", 1250.50},
+		{"1 250.50 руб.", 1250.50},
+		{"12 500 грн.", 12500},
+	}
+	for _, tt := range tests {
+		got, err := parseAmount(tt.in)
+		if err != nil {
+			t.Errorf("parseAmount(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseAmount(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "грн", "12#5", "1.2.3,4,5"} {
+		if v, err := parseAmount(in); err == nil {
+			t.Errorf("parseAmount(%q) = %v, want error", in, v)
+		}
+	}
+}
+
+func TestCargoUnmarshalPaymentValue(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    int
+		wantErr bool
+	}{
+		{`{"paymentValue": 12500}`, 12500, false},
+		{`{"paymentValue": 1250.6}`, 1251, false},
+		{`{"paymentValue": "12 500 грн"}`, 12500, false},
+		{`{"paymentValue": "1,250.50"}`, 1251, false},
+		{`{"paymentValue": "0.500"}`, 500, false},
+		{`{"paymentValue": "1,5"}`, 2, false},
+		{`{"paymentValue": "1 250,50 грн."}`, 1251, false},
+		{`{"paymentValue": "1,250.40 грн."}`, 1250, false},
+		{`{"paymentValue": "1.250,50 руб."}`, 1251, false},
+		{`{"paymentValue": "1 250.40 руб."}`, 1250, false},
+		{`{"paymentValue": ""}`, 0, false},
+		{`{"paymentValue": null}`, 0, false},
+		{`{}`, 0, false},
+		{`{"paymentValue": "n/a"}`, 0, true},
+		{`{"paymentValue": true}`, 0, true},
+	}
+	for _, tt := range tests {
+		var c Cargo
+		err := json.Unmarshal([]byte(tt.json), &c)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s): err = %v, wantErr %v", tt.json, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && c.PaymentValue != tt.want {
+			t.Errorf("Unmarshal(%s): PaymentValue = %d, want %d", tt.json, c.PaymentValue, tt.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package lardiAPI
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// AreaNode is an area with its nested areas, e.g. country → region → town
+type AreaNode struct {
+	ID          int
+	Name        string
+	CountrySign string
+	Children    []AreaNode
+}
+
+// GetAreaTree retrieves the areas reference preserving its hierarchy.
+// Nested entries and parent references in the response are both supported;
+// if the response is flat, areas are grouped under one node per country,
+// whose ID is 0 and Name is the country sign.
+func (c *Client) GetAreaTree(ctx context.Context, opts ...RequestOption) ([]AreaNode, error) {
+	var areas []Area
+	if err := c.getCached(ctx, pathAreas, &areas, opts...); err != nil {
+		return nil, fmt.Errorf("get area tree failed: %w", err)
+	}
+	return buildAreaTree(areas), nil
+}
+
+// getAreaList retrieves the areas reference as a flat list
+func (c *Client) getAreaList(ctx context.Context, opts ...RequestOption) ([]Area, error) {
+	var areas []Area
+	if err := c.getCached(ctx, pathAreas, &areas, opts...); err != nil {
+		return nil, err
+	}
+	return flattenAreas(areas), nil
+}
+
+func flattenAreas(areas []Area) []Area {
+	var flat []Area
+	for _, a := range areas {
+		children := a.Children
+		a.Children = nil
+		flat = append(flat, a)
+		flat = append(flat, flattenAreas(children)...)
+	}
+	return flat
+}
+
+func buildAreaTree(areas []Area) []AreaNode {
+	nested, linked := false, false
+	for _, a := range areas {
+		nested = nested || len(a.Children) > 0
+		linked = linked || a.ParentID != 0
+	}
+
+	switch {
+	case nested:
+		return nestedAreaNodes(areas)
+	case linked:
+		ids := make(map[int]bool, len(areas))
+		for _, a := range areas {
+			ids[a.ID] = true
+		}
+		// Areas whose parent is missing from the response become roots
+		children := make(map[int][]Area)
+		for _, a := range areas {
+			parent := a.ParentID
+			if !ids[parent] || parent == a.ID {
+				parent = 0
+			}
+			children[parent] = append(children[parent], a)
+		}
+		return linkedAreaNodes(children, 0)
+	}
+
+	var signs []string
+	byCountry := make(map[string][]AreaNode)
+	for _, a := range areas {
+		if _, ok := byCountry[a.CountrySign]; !ok {
+			signs = append(signs, a.CountrySign)
+		}
+		byCountry[a.CountrySign] = append(byCountry[a.CountrySign], areaNode(a))
+	}
+	sort.Strings(signs)
+	nodes := make([]AreaNode, 0, len(signs))
+	for _, sign := range signs {
+		nodes = append(nodes, AreaNode{Name: sign, CountrySign: sign, Children: byCountry[sign]})
+	}
+	return nodes
+}
+
+func nestedAreaNodes(areas []Area) []AreaNode {
+	nodes := make([]AreaNode, 0, len(areas))
+	for _, a := range areas {
+		node := areaNode(a)
+		node.Children = nestedAreaNodes(a.Children)
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func linkedAreaNodes(children map[int][]Area, parentID int) []AreaNode {
+	var nodes []AreaNode
+	for _, a := range children[parentID] {
+		node := areaNode(a)
+		if a.ID != 0 {
+			node.Children = linkedAreaNodes(children, a.ID)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func areaNode(a Area) AreaNode {
+	return AreaNode{ID: a.ID, Name: a.Name, CountrySign: a.CountrySign}
+}
@@ -0,0 +1,55 @@
+package lardiAPI
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path/filepath"
+)
+
+// MaxAttachmentSize is the largest file accepted by AttachCargoFile
+const MaxAttachmentSize = 10 << 20
+
+// ErrAttachmentTooLarge is returned when a file exceeds MaxAttachmentSize
+var ErrAttachmentTooLarge = errors.New("attachment exceeds maximum size")
+
+// AttachCargoFile uploads a photo or document to a cargo proposal.
+// The content type is detected from the file contents.
+func (c *Client) AttachCargoFile(
+	ctx context.Context, cargoID int, filename string, r io.Reader, opts ...RequestOption,
+) error {
+	data, err := io.ReadAll(io.LimitReader(r, MaxAttachmentSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read attachment: %w", err)
+	}
+	if len(data) > MaxAttachmentSize {
+		return fmt.Errorf("attach cargo file %q: %w", filename, ErrAttachmentTooLarge)
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, filepath.Base(filename)))
+	header.Set("Content-Type", http.DetectContentType(data))
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("failed to create multipart body: %w", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return fmt.Errorf("failed to create multipart body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to create multipart body: %w", err)
+	}
+
+	opts = append(opts[:len(opts):len(opts)], WithContentType(w.FormDataContentType()))
+	if err := c.post(ctx, fmt.Sprintf(pathCargoFiles, cargoID), body.Bytes(), nil, opts...); err != nil {
+		return fmt.Errorf("attach cargo file failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package lardiAPI
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// bulkFallbackConcurrency is the number of requests in flight when
+// CreateCargoBulk falls back to CreateCargoBatch
+const bulkFallbackConcurrency = 4
+
+// BatchResult is the outcome of one item of a batch operation
+type BatchResult struct {
+	// Index is the position of the item in the batch
+	Index int
+	// ID is the ID of the created or deleted proposal
+	ID int
+	// Started reports whether the request for the item was sent. Items still
+	// waiting for a Config.MaxConcurrentRequests slot or failing validation
+	// have not started. Items that never started because ctx was cancelled
+	// have Err set to ctx.Err().
+	Started bool
+	// Err is the error of the item; errors.Is(Err, context.Canceled) reports
+	// items cancelled in flight or before starting
+	Err error
+	// Skipped reports that the item was done according to the checkpoint
+	// and not sent again
+	Skipped bool
+}
+
+// BatchCheckpoint maps the indices of batch items already done to their
+// proposal IDs. It can be built from the results reported by WithProgress
+// and passed with WithCheckpoint to resume an interrupted batch.
+type BatchCheckpoint map[int]int
+
+// WithCheckpoint makes a batch skip the items done according to cp. Their
+// results have Skipped set and the ID from cp.
+func WithCheckpoint(cp BatchCheckpoint) RequestOption {
+	return func(o *requestOptions) {
+		o.checkpoint = cp
+	}
+}
+
+// WithProgress makes a batch call fn with the result of every item as soon
+// as it is done, including skipped ones, e.g. to persist a checkpoint. The
+// calls are not concurrent, so fn needs no locking, but fn blocks the batch
+// while it runs.
+func WithProgress(fn func(BatchResult)) RequestOption {
+	return func(o *requestOptions) {
+		o.progress = fn
+	}
+}
+
+// CreateCargoBatch creates the cargo proposals of reqs with up to
+// concurrency requests in flight and returns one result per request, in
+// order. Cancelling ctx stops the batch: requests in flight fail with the
+// context error, the remaining ones are not sent, and CreateCargoBatch
+// returns once no request is running anymore. WithCheckpoint and
+// WithProgress allow resuming an interrupted batch.
+func (c *Client) CreateCargoBatch(
+	ctx context.Context, reqs []*CargoRequest, concurrency int, opts ...RequestOption,
+) []BatchResult {
+	o := newRequestOptions(opts)
+	return runBatch(ctx, len(reqs), concurrency, o, func(ctx context.Context, i int) (int, error) {
+		resp, err := c.CreateCargo(ctx, reqs[i], opts...)
+		if err != nil {
+			return 0, err
+		}
+		return resp.ID, nil
+	})
+}
+
+// CreateCargoBulk creates the cargo proposals of reqs in a single request.
+// All requests are validated first; if any is invalid nothing is sent and
+// the error lists the invalid ones by index. The responses are in the order
+// of reqs. Proposals the server rejects have a zero ID in their response,
+// and their errors are joined into the returned error, each prefixed with
+// the index of the request. If the API has no bulk endpoint, the proposals
+// are created one by one with CreateCargoBatch.
+func (c *Client) CreateCargoBulk(
+	ctx context.Context, reqs []*CargoRequest, opts ...RequestOption,
+) ([]CargoResponse, error) {
+	var errs []error
+	for i, req := range reqs {
+		if err := req.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("cargo %d: %w", i, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	body := make([]*CargoRequest, len(reqs))
+	for i, req := range reqs {
+		c.warnCargo(req)
+		body[i] = c.withDefaults(req)
+	}
+	var items []struct {
+		CargoResponse
+		Error *APIError `json:"error,omitempty"`
+	}
+	err := c.post(ctx, pathCargoBulk, body, &items, opts...)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) &&
+		(apiErr.Status == http.StatusNotFound || apiErr.Status == http.StatusMethodNotAllowed) {
+		return c.createCargoOneByOne(ctx, reqs, opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create cargo bulk request failed: %w", err)
+	}
+	if len(items) != len(reqs) {
+		return nil, fmt.Errorf("create cargo bulk: got %d results for %d cargos", len(items), len(reqs))
+	}
+
+	resps := make([]CargoResponse, len(items))
+	for i, item := range items {
+		resps[i] = item.CargoResponse
+		if item.Error != nil {
+			resps[i].ID = 0
+			errs = append(errs, fmt.Errorf("cargo %d: %w", i, item.Error))
+		}
+	}
+	return resps, errors.Join(errs...)
+}
+
+// createCargoOneByOne is the fallback of CreateCargoBulk
+func (c *Client) createCargoOneByOne(
+	ctx context.Context, reqs []*CargoRequest, opts []RequestOption,
+) ([]CargoResponse, error) {
+	resps := make([]CargoResponse, len(reqs))
+	var errs []error
+	for _, r := range c.CreateCargoBatch(ctx, reqs, bulkFallbackConcurrency, opts...) {
+		resps[r.Index].ID = r.ID
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("cargo %d: %w", r.Index, r.Err))
+		}
+	}
+	return resps, errors.Join(errs...)
+}
+
+// DeleteCargoBatch deletes the cargo proposals with the given IDs with up to
+// concurrency requests in flight and returns one result per ID, in order.
+// An ID missing from the successfully deleted ones reported by the API is a
+// failure. Cancellation, checkpoints and progress work as in
+// CreateCargoBatch.
+func (c *Client) DeleteCargoBatch(
+	ctx context.Context, ids []int, concurrency int, opts ...RequestOption,
+) []BatchResult {
+	o := newRequestOptions(opts)
+	results := runBatch(ctx, len(ids), concurrency, o, func(ctx context.Context, i int) (int, error) {
+		resp, err := c.DeleteCargo(ctx, ids[i], opts...)
+		if err == nil && !slices.Contains(resp.Success, ids[i]) {
+			err = fmt.Errorf("cargo %d was not deleted", ids[i])
+		}
+		return ids[i], err
+	})
+	for i := range results {
+		results[i].ID = ids[i]
+	}
+	return results
+}
+
+// runBatch calls do for the items 0..n-1 with up to concurrency calls at
+// a time, skipping the items of the checkpoint option. It stops starting new
+// items when ctx is done and waits for the running ones before returning.
+func runBatch(
+	ctx context.Context, n, concurrency int, o *requestOptions,
+	do func(ctx context.Context, i int) (int, error),
+) []BatchResult {
+	results := make([]BatchResult, n)
+	for i := range results {
+		results[i].Index = i
+	}
+	concurrency = max(min(concurrency, n), 1)
+
+	var progressMu sync.Mutex
+	report := func(i int) {
+		if o.progress == nil {
+			return
+		}
+		progressMu.Lock()
+		defer progressMu.Unlock()
+		o.progress(results[i])
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				var started atomic.Bool
+				ctx := withSentHook(ctx, func() { started.Store(true) })
+				results[i].ID, results[i].Err = do(ctx, i)
+				results[i].Started = started.Load()
+				report(i)
+			}
+		}()
+	}
+
+	next := 0
+feed:
+	for ; next < n && ctx.Err() == nil; next++ {
+		if id, ok := o.checkpoint[next]; ok {
+			results[next].ID = id
+			results[next].Skipped = true
+			report(next)
+			continue
+		}
+		select {
+		case jobs <- next:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	for ; next < n; next++ {
+		if id, ok := o.checkpoint[next]; ok {
+			results[next].ID = id
+			results[next].Skipped = true
+			continue
+		}
+		results[next].Err = ctx.Err()
+	}
+	return results
+}
+
+type sentHookKey struct{}
+
+// withSentHook makes the requests of ctx call sent once they got a slot and
+// are sent, which tells batch items in flight from the waiting ones
+func withSentHook(ctx context.Context, sent func()) context.Context {
+	return context.WithValue(ctx, sentHookKey{}, sent)
+}
+
+// notifySent calls the hook of withSentHook, if any
+func notifySent(ctx context.Context) {
+	if sent, ok := ctx.Value(sentHookKey{}).(func()); ok {
+		sent()
+	}
+}
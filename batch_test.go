@@ -0,0 +1,71 @@
+package lardiAPI
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCreateCargoBatchCancel(t *testing.T) {
+	var requests atomic.Int32
+	inFlight := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Reading the body lets the server notice the client going away
+		io.Copy(io.Discard, r.Body)
+		if requests.Add(1) == 1 {
+			w.Write([]byte(`{"id":100}`))
+			return
+		}
+		// Hold the second request until the client gives up on it
+		close(inFlight)
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+	// One slot: the workers not sending wait for it
+	c := NewClient(Config{BaseURL: srv.URL, APIKey: "key", MaxConcurrentRequests: 1})
+
+	reqs := make([]*CargoRequest, 6)
+	for i := range reqs {
+		reqs[i] = testCargoRequest()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-inFlight
+		cancel()
+	}()
+	results := c.CreateCargoBatch(ctx, reqs, 3)
+
+	if len(results) != len(reqs) {
+		t.Fatalf("got %d results, want %d", len(results), len(reqs))
+	}
+	var done, cancelledInFlight, notStarted int
+	for i, r := range results {
+		if r.Index != i {
+			t.Errorf("results[%d].Index = %d", i, r.Index)
+		}
+		switch {
+		case r.Err == nil:
+			if !r.Started || r.ID != 100 {
+				t.Errorf("completed result %+v, want Started and ID 100", r)
+			}
+			done++
+		case !errors.Is(r.Err, context.Canceled):
+			t.Errorf("results[%d].Err = %v, want context.Canceled", i, r.Err)
+		case r.Started:
+			cancelledInFlight++
+		default:
+			notStarted++
+		}
+	}
+	if done != 1 || cancelledInFlight != 1 || notStarted != len(reqs)-2 {
+		t.Errorf("got %d done, %d cancelled in flight, %d not started; want 1, 1, %d",
+			done, cancelledInFlight, notStarted, len(reqs)-2)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("server got %d requests, want 2", n)
+	}
+}
@@ -0,0 +1,56 @@
+package lardiAPI
+
+import (
+	"context"
+	"fmt"
+)
+
+// BodyTypeMatch tells how the body types of CargoBodyTypeIDs are combined
+type BodyTypeMatch string
+
+// Body type match modes. The API treats the body types of a proposal as
+// alternatives unless told otherwise, so the empty mode means BodyTypeAny.
+const (
+	// BodyTypeAny accepts a vehicle with any of the listed body types
+	BodyTypeAny BodyTypeMatch = "any"
+	// BodyTypeAll requires a vehicle with all of the listed body types or
+	// features, e.g. a tent and a tail-lift
+	BodyTypeAll BodyTypeMatch = "all"
+)
+
+// BodyTypeGroup is a named group of related body types, e.g. refrigerated
+// variants
+type BodyTypeGroup struct {
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	BodyTypes []Response `json:"bodyTypes"`
+}
+
+// GetBodyTypeGroups retrieves the body types grouped by kind. The list is
+// cached per language.
+func (c *Client) GetBodyTypeGroups(ctx context.Context, opts ...RequestOption) ([]BodyTypeGroup, error) {
+	var resp []BodyTypeGroup
+	err := c.getCached(ctx, pathBodyTypeGroups, &resp, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("get body type groups failed: %w", err)
+	}
+	return resp, nil
+}
+
+// GetBodyTypesByGroup retrieves the body types of the group with the given
+// name, e.g. for a picker limited to refrigerated variants. The error matches
+// ErrReferenceNotFound if there is no such group.
+func (c *Client) GetBodyTypesByGroup(
+	ctx context.Context, groupName string, opts ...RequestOption,
+) ([]Response, error) {
+	groups, err := c.GetBodyTypeGroups(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		if namesMatch(g.Name, groupName, c.config.TransliterateNames) {
+			return g.BodyTypes, nil
+		}
+	}
+	return nil, fmt.Errorf("body type group %q: %w", groupName, ErrReferenceNotFound)
+}
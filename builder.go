@@ -0,0 +1,66 @@
+package lardiAPI
+
+// CargoBuilder assembles a CargoRequest step by step. A builder must be used
+// from a single goroutine; Build returns an independent snapshot that can be
+// shared and validated concurrently while the builder keeps changing.
+type CargoBuilder struct {
+	req CargoRequest
+}
+
+// NewCargoBuilder creates an empty CargoBuilder
+func NewCargoBuilder() *CargoBuilder {
+	return &CargoBuilder{}
+}
+
+// Dates sets the loading date range
+func (b *CargoBuilder) Dates(from, to string) *CargoBuilder {
+	b.req.DateFrom, b.req.DateTo = from, to
+	return b
+}
+
+// Payment sets the price, its currency and unit
+func (b *CargoBuilder) Payment(value, currencyID, unitID int) *CargoBuilder {
+	b.req.PaymentValue = value
+	b.req.PaymentCurrencyID = currencyID
+	b.req.PaymentUnitID = unitID
+	return b
+}
+
+// BodyTypes sets the accepted body type IDs
+func (b *CargoBuilder) BodyTypes(ids ...int) *CargoBuilder {
+	b.req.CargoBodyTypeIDs = append([]int(nil), ids...)
+	return b
+}
+
+// LoadTypes sets the load type IDs, e.g. from Client.LoadTypeIDs
+func (b *CargoBuilder) LoadTypes(ids ...int) *CargoBuilder {
+	b.req.LoadTypes = append([]int(nil), ids...)
+	return b
+}
+
+// Content sets the cargo description and its mass and volume
+func (b *CargoBuilder) Content(name string, mass, volume float64) *CargoBuilder {
+	b.req.ContentName = name
+	b.req.SizeMass = mass
+	b.req.SizeVolume = volume
+	return b
+}
+
+// From adds a loading point
+func (b *CargoBuilder) From(p LoadParams) *CargoBuilder {
+	b.req.WaypointListSource = append(b.req.WaypointListSource, p)
+	return b
+}
+
+// To adds an unloading point
+func (b *CargoBuilder) To(p LoadParams) *CargoBuilder {
+	b.req.WaypointListTarget = append(b.req.WaypointListTarget, p)
+	return b
+}
+
+// Build returns a deep copy of the request built so far and the result of
+// validating it
+func (b *CargoBuilder) Build() (*CargoRequest, error) {
+	req := MergeCargo(&b.req, nil)
+	return req, req.Validate()
+}
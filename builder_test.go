@@ -0,0 +1,37 @@
+package lardiAPI
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCargoBuilderConcurrentValidate(t *testing.T) {
+	b := NewCargoBuilder().
+		Dates("2024-11-10", "").
+		BodyTypes(1).
+		Content("Electronics", 10, 0).
+		From(LoadParams{TownName: "Київ", CountrySign: "UA", SizeMass: 10}).
+		To(LoadParams{TownName: "Львів", CountrySign: "UA"})
+	req, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := req.Validate(); err != nil {
+				t.Errorf("Validate: %v", err)
+			}
+		}()
+	}
+	// The builder keeps changing while the snapshot is validated
+	b.BodyTypes(2, 3).Content("Furniture", 20, 40).To(LoadParams{TownName: "Одеса", CountrySign: "UA"})
+	wg.Wait()
+
+	if req.ContentName != "Electronics" || len(req.CargoBodyTypeIDs) != 1 || len(req.WaypointListTarget) != 1 {
+		t.Errorf("snapshot changed with the builder: %+v", req)
+	}
+}
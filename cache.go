@@ -0,0 +1,236 @@
+package lardiAPI
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultCacheTTL = time.Hour
+
+// referenceCache stores raw reference responses keyed by language, API
+// version, path and query, and by the key of calls made with WithAPIKey
+type referenceCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+	// fetching serializes fetches of the same key, so that concurrent
+	// callers share one download
+	fetching map[string]*sync.Mutex
+	// noVersion is set once the reference version endpoint turned out to
+	// be unavailable
+	noVersion atomic.Bool
+}
+
+type cacheEntry struct {
+	body         json.RawMessage
+	lastModified string
+	version      string
+	expiresAt    time.Time
+}
+
+func newReferenceCache(ttl time.Duration) *referenceCache {
+	return &referenceCache{
+		ttl:      ttl,
+		entries:  make(map[string]cacheEntry),
+		fetching: make(map[string]*sync.Mutex),
+	}
+}
+
+// load returns the cached entry for key. Expired entries are returned too,
+// so that callers can revalidate them or compare them with new data.
+func (rc *referenceCache) load(key string) (cacheEntry, bool) {
+	if rc.ttl < 0 {
+		return cacheEntry{}, false
+	}
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	e, ok := rc.entries[key]
+	return e, ok
+}
+
+func (rc *referenceCache) store(key string, body json.RawMessage, lastModified, version string) {
+	if rc.ttl < 0 {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries[key] = cacheEntry{
+		body:         body,
+		lastModified: lastModified,
+		version:      version,
+		expiresAt:    time.Now().Add(rc.ttl),
+	}
+}
+
+// lockKey serializes fetches of key and returns the unlock function
+func (rc *referenceCache) lockKey(key string) func() {
+	rc.mu.Lock()
+	m, ok := rc.fetching[key]
+	if !ok {
+		m = &sync.Mutex{}
+		rc.fetching[key] = m
+	}
+	rc.mu.Unlock()
+
+	m.Lock()
+	return m.Unlock
+}
+
+func (e cacheEntry) fresh() bool {
+	return time.Now().Before(e.expiresAt)
+}
+
+func (rc *referenceCache) clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries = make(map[string]cacheEntry)
+}
+
+// InvalidateReferences drops all cached reference data
+func (c *Client) InvalidateReferences() {
+	c.cache.clear()
+}
+
+// getCached performs a GET request for reference data, serving it from the
+// cache when a fresh entry for the current language and API version exists
+func (c *Client) getCached(
+	ctx context.Context, path string, result interface{}, opts ...RequestOption,
+) error {
+	return c.getReference(ctx, path, false, result, opts...)
+}
+
+// getReference fetches reference data and updates the cache. When refresh is
+// true the cache is bypassed. IDs that disappear from a previously cached
+// list are reported to the logger, since requests built from them will fail.
+//
+// Paginated references are fetched page by page and cached as one list.
+//
+// An expired entry is kept for another TTL if the reference version reported
+// by the server has not changed since the entry was stored. Otherwise, if the
+// server sent Last-Modified, it is revalidated with If-Modified-Since and
+// reused on 304 Not Modified.
+func (c *Client) getReference(
+	ctx context.Context, path string, refresh bool, result interface{}, opts ...RequestOption,
+) error {
+	o := newRequestOptions(opts)
+	lang := c.language.String()
+	if o.noLanguage {
+		lang = ""
+	}
+	key := lang + " " + c.apiVersion(o) + path
+	if len(o.query) > 0 {
+		key += "?" + o.query.Encode()
+	}
+	if o.apiKey != "" {
+		// Keep the data of different accounts apart
+		sum := sha256.Sum256([]byte(o.apiKey))
+		key += " key:" + hex.EncodeToString(sum[:8])
+	}
+	entry, cached := c.cache.load(key)
+	if !refresh && (!cached || !entry.fresh()) {
+		unlock := c.cache.lockKey(key)
+		defer unlock()
+		// Another caller may have fetched it while we were waiting
+		entry, cached = c.cache.load(key)
+	}
+	var version string
+	if !refresh && cached && !entry.fresh() {
+		version = c.referenceVersion(ctx, o)
+		if version != "" && version == entry.version {
+			c.cache.store(key, entry.body, entry.lastModified, version)
+			return c.decode(entry.body, result, "response")
+		}
+	}
+	if refresh || !cached || !entry.fresh() {
+		var (
+			fetched      json.RawMessage
+			lastModified string
+		)
+		fetchOpts := append(opts[:len(opts):len(opts)], withResponse(func(resp *http.Response) {
+			lastModified = resp.Header.Get("Last-Modified")
+		}))
+		if cached && !refresh && entry.lastModified != "" {
+			fetchOpts = append(fetchOpts, withHeader("If-Modified-Since", entry.lastModified))
+		}
+
+		err := c.get(ctx, path, &fetched, fetchOpts...)
+		if err == nil {
+			fetched, err = c.allPages(ctx, path, fetched, opts)
+		}
+		switch {
+		case errors.Is(err, errNotModified):
+			fetched = entry.body
+			if lastModified == "" {
+				lastModified = entry.lastModified
+			}
+		case err != nil:
+			return err
+		default:
+			fetched = c.withFallbackNames(ctx, path, fetched, opts)
+			if cached {
+				c.warnRemovedIDs(path, entry.body, fetched)
+			}
+		}
+		c.cache.store(key, fetched, lastModified, version)
+		entry.body = fetched
+	}
+
+	return c.decode(entry.body, result, "response")
+}
+
+// GetReferenceVersion retrieves the version of the reference data, which
+// changes whenever a reference list changes server-side
+func (c *Client) GetReferenceVersion(ctx context.Context, opts ...RequestOption) (string, error) {
+	var resp struct {
+		Version string `json:"version"`
+	}
+	err := c.get(ctx, pathRefVersion, &resp, opts...)
+	if err != nil {
+		return "", fmt.Errorf("get reference version failed: %w", err)
+	}
+	return resp.Version, nil
+}
+
+// referenceVersion returns the current reference version for revalidating
+// cache entries, or an empty string if it cannot be determined, in which
+// case the entries expire by TTL alone. It is requested with the identity
+// of the reference call o, without its outputs and query.
+func (c *Client) referenceVersion(ctx context.Context, o *requestOptions) string {
+	if c.cache.noVersion.Load() {
+		return ""
+	}
+	version, err := c.GetReferenceVersion(ctx, o.identity())
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			c.cache.noVersion.Store(true)
+		}
+		return ""
+	}
+	return version
+}
+
+// warnRemovedIDs logs reference IDs present in old but missing from updated
+func (c *Client) warnRemovedIDs(path string, old, updated json.RawMessage) {
+	var before, after []Response
+	if json.Unmarshal(old, &before) != nil || json.Unmarshal(updated, &after) != nil {
+		return
+	}
+	var removed []int
+	for _, v := range before {
+		if findByID(after, v.ID) == nil {
+			removed = append(removed, v.ID)
+		}
+	}
+	if len(removed) > 0 {
+		c.config.Logger.Warn("lardiAPI: reference IDs no longer exist",
+			"path", path, "language", c.config.Language, "ids", removed)
+	}
+}
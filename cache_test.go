@@ -0,0 +1,68 @@
+package lardiAPI
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReferenceVersionOmitsCallOutputs(t *testing.T) {
+	var (
+		mu           sync.Mutex
+		versionQuery url.Values
+		versionAuth  string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, pathRefVersion) {
+			mu.Lock()
+			versionQuery, versionAuth = r.URL.Query(), r.Header.Get("Authorization")
+			mu.Unlock()
+			w.Write([]byte(`{"version":"2"}`))
+			return
+		}
+		w.Write([]byte(`[{"id":1,"name":"UAH"}]`))
+	}))
+	defer srv.Close()
+	c := NewClient(Config{BaseURL: srv.URL, APIKey: "key", CacheTTL: time.Nanosecond})
+
+	// The cache is kept per query and key, so both calls use the same
+	opts := []RequestOption{WithQuery("filter", "all"), WithAPIKey("other")}
+	var list []Response
+	if err := c.getCached(context.Background(), pathCurrencies, &list, opts...); err != nil {
+		t.Fatalf("getCached: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+
+	var (
+		ref    url.URL
+		timing Timing
+	)
+	err := c.getCached(context.Background(), pathCurrencies, &list,
+		append(opts, WithURLRef(&ref), WithTiming(&timing))...)
+	if err != nil {
+		t.Fatalf("getCached: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if versionQuery == nil {
+		t.Fatal("reference version was not requested")
+	}
+	if versionQuery.Has("filter") || versionQuery.Get("language") != "uk" {
+		t.Errorf("version request query = %v, want only the language", versionQuery)
+	}
+	if versionAuth != "other" {
+		t.Errorf("version request key = %q, want the key of the call", versionAuth)
+	}
+	if !strings.HasSuffix(ref.Path, pathCurrencies) || ref.Query().Get("filter") != "all" {
+		t.Errorf("URL ref = %s, want the currencies request", ref.String())
+	}
+	if len(timing.Attempts) != 1 {
+		t.Errorf("timing has %d attempts, want only the currencies request", len(timing.Attempts))
+	}
+}
@@ -0,0 +1,427 @@
+package lardiAPI
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// ErrAlreadyBooked is returned by BookCargo when the proposal has already
+// been booked
+var ErrAlreadyBooked = errors.New("cargo already booked")
+
+// CargoStatus is the lifecycle status of a cargo proposal
+type CargoStatus int
+
+// Cargo proposal statuses
+const (
+	CargoStatusUnknown CargoStatus = iota
+	CargoStatusActive
+	CargoStatusExpired
+	CargoStatusArchived
+	CargoStatusDraft
+)
+
+var cargoStatusNames = map[CargoStatus]string{
+	CargoStatusUnknown:  "unknown",
+	CargoStatusActive:   "active",
+	CargoStatusExpired:  "expired",
+	CargoStatusArchived: "archived",
+	CargoStatusDraft:    "draft",
+}
+
+// String returns the lower-case name of the status
+func (s CargoStatus) String() string {
+	if name, ok := cargoStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("CargoStatus(%d)", int(s))
+}
+
+// ParseCargoStatus converts a status name to a CargoStatus. The API's
+// "published" is accepted as an alias of active.
+func ParseCargoStatus(name string) (CargoStatus, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "published" {
+		return CargoStatusActive, nil
+	}
+	for s, n := range cargoStatusNames {
+		if n == name {
+			return s, nil
+		}
+	}
+	return CargoStatusUnknown, fmt.Errorf("unknown cargo status %q", name)
+}
+
+// MarshalJSON encodes the status as its name
+func (s CargoStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON decodes the status from either its name or numeric code.
+// Unrecognized values decode to CargoStatusUnknown.
+func (s *CargoStatus) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*s, _ = ParseCargoStatus(name)
+		return nil
+	}
+	var code int
+	if err := json.Unmarshal(data, &code); err != nil {
+		return fmt.Errorf("invalid cargo status %s", data)
+	}
+	*s = CargoStatus(code)
+	if _, ok := cargoStatusNames[*s]; !ok {
+		*s = CargoStatusUnknown
+	}
+	return nil
+}
+
+// Representation selects how much of a created or updated resource the API
+// returns
+type Representation string
+
+// Representations for Config.PreferRepresentation
+const (
+	RepresentationMinimal Representation = "minimal"
+	RepresentationFull    Representation = "full"
+)
+
+// preference returns the value of the Prefer header
+func (r Representation) preference() string {
+	if r == RepresentationFull {
+		return "return=representation"
+	}
+	return "return=minimal"
+}
+
+// Cargo represents a published cargo proposal
+type Cargo struct {
+	ID                 int           `json:"id"`
+	Status             CargoStatus   `json:"status"`
+	ContactID          int           `json:"contactId"`
+	BranchID           int           `json:"branchId"`
+	ContactPhone       string        `json:"contactPhone"`
+	ContactFace        string        `json:"contactFace"`
+	DateFrom           string        `json:"dateFrom"`
+	DateTo             string        `json:"dateTo"`
+	PaymentValue       int           `json:"paymentValue"`
+	PaymentCurrencyID  int           `json:"paymentCurrencyId"`
+	PaymentUnitID      int           `json:"paymentUnitId"`
+	PaymentMomentID    int           `json:"paymentMomentId"`
+	CargoBodyTypeIDs   []int         `json:"cargoBodyTypeIds"`
+	CargoBodyTypeMatch BodyTypeMatch `json:"cargoBodyTypeMatch"`
+	CargoPackaging     []CargoPack   `json:"cargoPackaging"`
+	PaymentForms       []PaymentForm `json:"paymentForms"`
+	LorryAmount        int           `json:"lorryAmount"`
+	LoadTypes          []int         `json:"loadTypes"`
+	Groupage           bool          `json:"groupage"`
+	ContentName        string        `json:"contentName"`
+	SizeMass           float64       `json:"sizeMass"`
+	SizeVolume         float64       `json:"sizeVolume"`
+	WaypointListSource []LoadParams  `json:"waypointListSource"`
+	WaypointListTarget []LoadParams  `json:"waypointListTarget"`
+	// URL is the web page of the proposal on the platform
+	URL string `json:"url"`
+}
+
+// UnmarshalJSON decodes a cargo, accepting the price either as a number or
+// as a formatted string such as "12 500 грн" or "1,250.50", which listing
+// responses may contain. Fractional prices are rounded.
+func (c *Cargo) UnmarshalJSON(data []byte) error {
+	type plain Cargo
+	aux := struct {
+		*plain
+		PaymentValue json.RawMessage `json:"paymentValue"`
+	}{plain: (*plain)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	c.PaymentValue = 0
+	var price interface{}
+	if len(aux.PaymentValue) > 0 {
+		if err := json.Unmarshal(aux.PaymentValue, &price); err != nil {
+			return err
+		}
+	}
+	switch v := price.(type) {
+	case float64:
+		c.PaymentValue = int(math.Round(v))
+	case string:
+		if strings.TrimSpace(v) == "" {
+			break
+		}
+		amount, err := parseAmount(v)
+		if err != nil {
+			return fmt.Errorf("paymentValue: %w", err)
+		}
+		c.PaymentValue = int(math.Round(amount))
+	case nil:
+	default:
+		return fmt.Errorf("paymentValue: unexpected value %s", aux.PaymentValue)
+	}
+	return nil
+}
+
+// ListMyCargos retrieves one page of the account's cargo proposals.
+// Pass a zero PageInfo for the first page and the result of Next() for the
+// following ones. With WithLenientDecoding, malformed records are skipped
+// and reported in a *RecordErrors returned along with the other cargos.
+func (c *Client) ListMyCargos(
+	ctx context.Context, page PageInfo, opts ...RequestOption,
+) ([]Cargo, *PageInfo, error) {
+	return c.listMyCargos(ctx, nil, page, opts)
+}
+
+// ListMyCargoFields is like ListMyCargos, but asks the API to return only the
+// given fields of every cargo, named as in the JSON of Cargo, e.g. "id" and
+// "status". The other fields are left zero. Unknown names are rejected
+// without sending the request.
+func (c *Client) ListMyCargoFields(
+	ctx context.Context, page PageInfo, fields []string, opts ...RequestOption,
+) ([]Cargo, *PageInfo, error) {
+	if err := checkFields(reflect.TypeOf(Cargo{}), fields); err != nil {
+		return nil, nil, err
+	}
+	var query url.Values
+	if len(fields) > 0 {
+		query = url.Values{"fields": {strings.Join(fields, ",")}}
+	}
+	return c.listMyCargos(ctx, query, page, opts)
+}
+
+func (c *Client) listMyCargos(
+	ctx context.Context, query url.Values, page PageInfo, opts []RequestOption,
+) ([]Cargo, *PageInfo, error) {
+	var cargos []Cargo
+	info, err := c.getPage(ctx, pathMyCargos, query, page, &cargos, opts...)
+	if err != nil && isRecordErrors(err) {
+		return cargos, info, fmt.Errorf("list my cargos: %w", err)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("list my cargos failed: %w", err)
+	}
+	return cargos, info, nil
+}
+
+// checkFields reports field names that are not JSON fields of struct type t
+func checkFields(t reflect.Type, fields []string) error {
+	known := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		known[jsonName(t.Field(i))] = true
+	}
+	for _, f := range fields {
+		if !known[f] {
+			return fmt.Errorf("unknown %s field %q", t.Name(), f)
+		}
+	}
+	return nil
+}
+
+// IterateMyCargos returns an iterator over all of the account's cargo
+// proposals starting from page
+func (c *Client) IterateMyCargos(
+	ctx context.Context, page PageInfo, opts ...RequestOption,
+) *Iterator[Cargo] {
+	return newIterator(ctx, page, func(ctx context.Context, page PageInfo) ([]Cargo, *PageInfo, error) {
+		return c.ListMyCargos(ctx, page, opts...)
+	})
+}
+
+// StreamMyCargos pages through all of the account's cargo proposals in the
+// background and sends them on the returned channel, which is closed when the
+// listing is exhausted. If a page fails or ctx is cancelled, the error is sent
+// on the error channel, which is closed after the cargo channel.
+func (c *Client) StreamMyCargos(
+	ctx context.Context, opts ...RequestOption,
+) (<-chan Cargo, <-chan error) {
+	cargos := make(chan Cargo)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+		defer close(cargos)
+
+		it := c.IterateMyCargos(ctx, PageInfo{}, opts...)
+		for it.Next() {
+			select {
+			case cargos <- it.Item():
+			case <-ctx.Done():
+				errc <- ctx.Err()
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			errc <- err
+		}
+	}()
+
+	return cargos, errc
+}
+
+// GetCargo retrieves one of the account's cargo proposals. The error matches
+// ErrNotFound if the proposal does not exist.
+func (c *Client) GetCargo(ctx context.Context, id int, opts ...RequestOption) (*Cargo, error) {
+	var resp Cargo
+	err := c.get(ctx, fmt.Sprintf(pathMyCargo, id), &resp, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("get cargo failed: %w", err)
+	}
+	return &resp, nil
+}
+
+// GetCargoURL returns the web page of a cargo proposal on the platform, as
+// provided by the API, for storing and sharing a clickable link. The error
+// matches ErrNotFound if the proposal does not exist.
+func (c *Client) GetCargoURL(ctx context.Context, id int, opts ...RequestOption) (string, error) {
+	cargo, err := c.GetCargo(ctx, id, opts...)
+	if err != nil {
+		return "", err
+	}
+	if cargo.URL == "" {
+		return "", fmt.Errorf("cargo %d: the API did not provide its URL", id)
+	}
+	return cargo.URL, nil
+}
+
+// CreateCargoFull creates a cargo proposal and returns it as stored by the
+// server, fetching it by ID unless the response already contains it. If the
+// proposal was created but fetching it fails, the returned Cargo has only its
+// ID set and the error describes the failed fetch.
+func (c *Client) CreateCargoFull(
+	ctx context.Context, req *CargoRequest, opts ...RequestOption,
+) (*Cargo, error) {
+	resp, err := c.CreateCargo(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Cargo != nil {
+		return resp.Cargo, nil
+	}
+	cargo, err := c.GetCargo(ctx, resp.ID, opts...)
+	if err != nil {
+		return &Cargo{ID: resp.ID}, fmt.Errorf("cargo %d created, but fetching it failed: %w", resp.ID, err)
+	}
+	return cargo, nil
+}
+
+// CargoStats holds the activity counters of a cargo proposal
+type CargoStats struct {
+	Views     int `json:"views"`
+	Contacts  int `json:"contacts"`
+	Responses int `json:"responses"`
+}
+
+// GetCargoStats retrieves views, contact and response counts of a cargo
+// proposal. The error matches ErrNotFound if the proposal does not exist.
+func (c *Client) GetCargoStats(
+	ctx context.Context, id int, opts ...RequestOption,
+) (*CargoStats, error) {
+	var resp CargoStats
+	err := c.get(ctx, fmt.Sprintf(pathCargoStats, id), &resp, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("get cargo stats failed: %w", err)
+	}
+	return &resp, nil
+}
+
+// GetCargoShareLink retrieves a public link to a cargo proposal that can be
+// shared with carriers outside the platform. The error matches ErrNotFound
+// if the proposal does not exist.
+func (c *Client) GetCargoShareLink(ctx context.Context, id int, opts ...RequestOption) (string, error) {
+	var resp struct {
+		URL string `json:"url"`
+	}
+	err := c.get(ctx, fmt.Sprintf(pathCargoShare, id), &resp, opts...)
+	if err != nil {
+		return "", fmt.Errorf("get cargo share link failed: %w", err)
+	}
+	return resp.URL, nil
+}
+
+// CargoContactResponse is a carrier's response to a cargo proposal with the
+// contact details to reach the carrier
+type CargoContactResponse struct {
+	ID int `json:"id"`
+	// Name is the name of the contact person
+	Name        string   `json:"name"`
+	ContactID   int      `json:"contactId"`
+	CompanyName string   `json:"companyName,omitempty"`
+	Phones      []string `json:"phones,omitempty"`
+	Email       string   `json:"email,omitempty"`
+	Comment     string   `json:"comment,omitempty"`
+	// CreatedAt is the time of the response as sent by the API
+	CreatedAt string `json:"dateCreate,omitempty"`
+}
+
+// GetCargoResponses retrieves the carrier responses to a cargo proposal with
+// their contact details. The error matches ErrNotFound if the proposal does
+// not exist.
+func (c *Client) GetCargoResponses(
+	ctx context.Context, id int, opts ...RequestOption,
+) ([]CargoContactResponse, error) {
+	var resp []CargoContactResponse
+	err := c.get(ctx, fmt.Sprintf(pathResponses, id), &resp, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("get cargo responses failed: %w", err)
+	}
+	return resp, nil
+}
+
+// BookCargo closes a cargo proposal as booked by the carrier with the given
+// contact ID. The error matches ErrAlreadyBooked if the proposal has already
+// been booked and ErrNotFound if it does not exist.
+func (c *Client) BookCargo(
+	ctx context.Context, id int, carrierContactID int, opts ...RequestOption,
+) error {
+	body := struct {
+		CarrierContactID int `json:"carrierContactId"`
+	}{CarrierContactID: carrierContactID}
+
+	err := c.post(ctx, fmt.Sprintf(pathBook, id), body, nil, opts...)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Status == http.StatusConflict {
+		return fmt.Errorf("book cargo failed: %w: %w", ErrAlreadyBooked, err)
+	}
+	if err != nil {
+		return fmt.Errorf("book cargo failed: %w", err)
+	}
+	return nil
+}
+
+// CreateCargoDraft saves a cargo proposal as a draft that is not yet visible
+// to carriers. Only the waypoints are required, so the route can be saved
+// first and the remaining fields filled in later with UpdateCargo.
+func (c *Client) CreateCargoDraft(
+	ctx context.Context, req *CargoRequest, opts ...RequestOption,
+) (*CargoResponse, error) {
+	if err := req.validate(true); err != nil {
+		return nil, err
+	}
+	c.warnCargo(req)
+	req = c.withDefaults(req)
+	var resp CargoResponse
+	err := c.post(ctx, pathCargoDraft, req, &resp, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create cargo draft failed: %w", err)
+	}
+	return &resp, nil
+}
+
+// PublishCargoDraft publishes a draft created with CreateCargoDraft. The API
+// rejects drafts that are still incomplete with a *ValidationError.
+func (c *Client) PublishCargoDraft(ctx context.Context, id int, opts ...RequestOption) (*CargoResponse, error) {
+	var resp CargoResponse
+	err := c.post(ctx, fmt.Sprintf(pathPublish, id), struct{}{}, &resp, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("publish cargo draft failed: %w", err)
+	}
+	return &resp, nil
+}
@@ -5,47 +5,222 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
-
-	"github.com/go-playground/validator/v10"
 )
 
 // API endpoints
 const (
-	defaultBaseURL = "https://api.lardi-trans.com"
-	defaultTimeout = 30 * time.Second
+	defaultBaseURL    = "https://api.lardi-trans.com"
+	defaultAPIVersion = "v2"
+	defaultTimeout    = 30 * time.Second
+
+	defaultRequestIDHeader = "X-Request-ID"
+	defaultAuthHeaderName  = "Authorization"
+	defaultMaxIdleConns    = 100
+	defaultRetryWait       = 500 * time.Millisecond
+	defaultRetryMaxDelay   = 30 * time.Second
 )
 
 // Endpoint paths
 const (
-	pathCargo        = "/v2/proposals/my/add/cargo"
-	pathCurrencies   = "/v2/references/currencies"
-	pathUnits        = "/v2/references/payment/units"
-	pathMoments      = "/v2/references/payment/moments"
-	pathTypes        = "/v2/references/body/types"
-	pathPackage      = "/v2/references/cargo/package"
-	pathTypesPayment = "/v2/references/payment/types"
-	pathLoadTypes    = "/v2/references/load/types"
-	pathAreas        = "/v2/references/areas"
-	pathContacts     = "/v2/users/user/contacts"
-	pathDelete       = "/v2/proposals/my/basket/throw"
-	pathUpdate       = "/v2/proposals/my/cargo/%s/%d"
+	pathCargo          = "/proposals/my/add/cargo"
+	pathCargoBulk      = "/proposals/my/add/cargoes"
+	pathCurrencies     = "/references/currencies"
+	pathUnits          = "/references/payment/units"
+	pathMoments        = "/references/payment/moments"
+	pathTypes          = "/references/body/types"
+	pathBodyTypeGroups = "/references/body/types/groups"
+	pathPackage        = "/references/cargo/package"
+	pathTypesPayment   = "/references/payment/types"
+	pathPaymentForms   = "/references/payment/forms"
+	pathLoadTypes      = "/references/load/types"
+	pathAreas          = "/references/areas"
+	pathRefVersion     = "/references/version"
+	pathContacts       = "/users/user/contacts"
+	pathUser           = "/users/user"
+	pathBranches       = "/users/user/branches"
+	pathDelete         = "/proposals/my/basket/throw"
+	pathUpdate         = "/proposals/my/cargo/%s/%d"
+	pathMyCargos       = "/proposals/my/cargoes"
+	pathMyCargo        = "/proposals/my/cargo/%d"
+	pathCargoFiles     = "/proposals/my/cargo/%d/files"
+	pathCargoStats     = "/proposals/my/cargo/%d/statistics"
+	pathCargoShare     = "/proposals/my/cargo/%d/share"
+	pathResponses      = "/proposals/my/cargo/%d/responses"
+	pathBook           = "/proposals/my/cargo/%d/book"
+	pathCargoDraft     = "/proposals/my/add/cargo/draft"
+	pathPublish        = "/proposals/my/cargo/%d/publish"
+	pathDeals          = "/proposals/my/deals"
+	pathSearchCargo    = "/proposals/search/cargo"
+	pathWebhooks       = "/webhooks"
+	pathWebhook        = "/webhooks/%d"
 )
 
 // Config contains the configuration for the API client
 type Config struct {
-	BaseURL  string
-	APIKey   string
-	Timeout  time.Duration
+	// BaseURL is the API root, optionally with a path prefix such as
+	// "https://gw.internal/lardi"; a trailing slash is ignored.
+	// Defaults to "https://api.lardi-trans.com".
+	BaseURL string
+	// APIVersion is the path prefix of every endpoint, e.g. "v2". Single
+	// calls can use another version with WithAPIVersion. Defaults to "v2".
+	APIVersion string
+	APIKey     string
+	// AuthHeaderName is the header carrying the API key, e.g. "X-API-Key"
+	// behind gateways that use Authorization themselves.
+	// Defaults to "Authorization".
+	AuthHeaderName string
+	Timeout        time.Duration
+	// Language is the code of the language of the API responses, e.g. "uk"
+	// or LanguageRU.String(). It is normalized with ParseLanguage; unknown
+	// values fall back to LanguageUK with a warning. Defaults to "uk".
 	Language string
+	// TimeZone is the zone in which SetCargoDates takes the calendar days of
+	// the given times, typically the zone of the users. The API treats dates
+	// as calendar days in Ukraine (Europe/Kyiv), which is the default.
+	TimeZone *time.Location
+	// Logger receives warnings about the client configuration and responses.
+	// Defaults to slog.Default().
+	Logger *slog.Logger
+	// RequestIDContextKey is the context key under which callers store a
+	// request ID. When set and the request context holds a string value for
+	// it, the value is sent in the RequestIDHeader header.
+	RequestIDContextKey interface{}
+	// RequestIDHeader is the header used for the request ID.
+	// Defaults to "X-Request-ID".
+	RequestIDHeader string
+	// CacheTTL controls how long reference data is cached per language.
+	// Defaults to one hour; a negative value disables caching.
+	CacheTTL time.Duration
+	// HTTPClient replaces the default HTTP client. When set, Timeout,
+	// MaxIdleConns and MaxConnsPerHost are not applied.
+	HTTPClient HTTPClient
+	// MaxIdleConns limits idle keep-alive connections to the API.
+	// Defaults to 100.
+	MaxIdleConns int
+	// MaxConnsPerHost limits the total number of connections to the API.
+	// Defaults to 0, meaning no limit.
+	MaxConnsPerHost int
+	// MaxConcurrentRequests limits the number of requests in flight at once.
+	// Further requests wait for a free slot, in the order of their priority
+	// (see WithPriority), or until their context is done.
+	// Defaults to 0, meaning no limit.
+	MaxConcurrentRequests int
+	// OnDeprecation is called when a response carries Deprecation or
+	// Sunset headers. The notice is also logged as a warning.
+	OnDeprecation func(DeprecationNotice)
+	// MaxRetries is the number of times a failed request is retried after
+	// a network error or an API error accepted by RetryPolicy (by default
+	// 429 or 5xx). Defaults to 0 (no retries).
+	// POST requests are not retried unless DedupeOnRetry is set.
+	MaxRetries int
+	// RetryOverrides sets the number of retries for requests of an endpoint
+	// category, e.g. more retries for CategoryReferences. WithRetries takes
+	// precedence over it, and it takes precedence over MaxRetries.
+	RetryOverrides map[string]int
+	// RetryPolicy decides which API errors are retried, e.g. on application
+	// error codes. Defaults to DefaultRetryPolicy.
+	RetryPolicy RetryPolicy
+	// RetryWait is the base delay between retries, doubled on every attempt.
+	// Defaults to 500ms.
+	RetryWait time.Duration
+	// RetryMaxDelay caps the delay between retries. Defaults to 30s.
+	RetryMaxDelay time.Duration
+	// RetryJitter randomizes retry delays. Defaults to JitterFull.
+	RetryJitter Jitter
+	// DedupeOnRetry allows CreateCargo to be retried. Before each retry the
+	// account's cargos are checked for one matching the request, and if it
+	// was already created its ID is returned instead of posting again.
+	DedupeOnRetry bool
+	// OnRetry is called before waiting for each retry with the retry number
+	// (starting at 1), the error of the failed attempt and the delay.
+	OnRetry func(attempt int, err error, next time.Duration)
+	// JSON encodes request bodies and decodes responses.
+	// Defaults to encoding/json.
+	JSON JSONCodec
+	// KeyProvider supplies the API key for every request, e.g. rotating
+	// over several accounts with RoundRobinKeys. Defaults to StaticKey(APIKey).
+	KeyProvider KeyProvider
+	// RetryUnauthorized retries a request once with the next key from
+	// KeyProvider when the API responds with 401 Unauthorized.
+	RetryUnauthorized bool
+	// TimeoutOverrides sets the time limit, including retries, for requests
+	// of an endpoint category such as CategoryReferences or
+	// CategoryCargoWrite. Timeout still limits every single attempt.
+	TimeoutOverrides map[string]time.Duration
+	// RateProvider supplies exchange rates for GetExchangeRates and
+	// ConvertPrice. Lardi-Trans does not publish rates, so it has no default.
+	RateProvider RateProvider
+	// RateCacheTTL controls how long exchange rates are cached.
+	// Defaults to 5 minutes.
+	RateCacheTTL time.Duration
+	// PreferRepresentation is sent in the Prefer header of POST and PUT
+	// requests to ask for a minimal or full response body. Empty by default,
+	// leaving the choice to the server.
+	PreferRepresentation Representation
+	// TransliterateNames makes the name lookups of references and areas also
+	// compare names transliterated to Latin, so that names typed with a mix
+	// of Cyrillic and Latin letters match
+	TransliterateNames bool
+	// StrictPrecheck makes PrecheckCargo fail on advisory findings, such as
+	// a payment unit that does not fit the currency, instead of logging them
+	StrictPrecheck bool
+	// DefaultBranchID is the branch of the account that cargo proposals are
+	// posted under when CargoRequest.BranchID is not set. Zero leaves the
+	// choice to the server, which suits single-branch accounts.
+	DefaultBranchID int
+	// FallbackLanguage is used for reference data that has no usable names
+	// in Language: an empty list is fetched again in FallbackLanguage, and
+	// entries with empty names get their names from it. It is normalized
+	// with ParseLanguage; unknown values disable the fallback with a
+	// warning. Disabled by default.
+	FallbackLanguage Language
+	// RedactFields lists the JSON fields whose values are masked in request
+	// and response bodies logged at debug level and quoted in decode errors.
+	// Names match case-insensitively at any depth. Defaults to
+	// DefaultRedactFields; an empty non-nil list disables redaction.
+	RedactFields []string
+	// PrettyPrintRequests indents the JSON bodies of POST and PUT requests,
+	// which makes them easier to read in hooks and debug logs. It is meant
+	// for debugging only, as it makes every request larger.
+	PrettyPrintRequests bool
+	// Offline makes the client answer every call with deterministic stub
+	// data instead of sending requests, for tests of code that builds
+	// requests. Inputs are still validated. Created, updated and published
+	// proposals get IDs counting from 1, DeleteCargo succeeds for every ID,
+	// GetCargo returns a proposal with only the requested ID, listings are
+	// empty, every reference list holds one entry {1, "stub"} and the
+	// remaining calls return zero values. HTTPClient is ignored.
+	Offline bool
 }
 
 // Client represents a client for the Lardi-Trans API
 type Client struct {
 	config Config
-	http   HTTPClient
+	// language is the normalized Config.Language
+	language Language
+	http     HTTPClient
+	cache    *referenceCache
+	// slots limits the requests in flight when MaxConcurrentRequests is set
+	slots *slotQueue
+
+	mu          sync.Mutex
+	deprecation *DeprecationNotice
+	templates   map[string]*CargoRequest
+	rates       rateCache
+	clockSkew   time.Duration
+	skewWarned  bool
+	// deprecationWarned holds the "METHOD path" of deprecated endpoints
+	// already logged
+	deprecationWarned map[string]bool
 }
 
 // HTTPClient interface allows for easy mocking in tests
@@ -58,24 +233,107 @@ func NewClient(config Config) *Client {
 	if config.BaseURL == "" {
 		config.BaseURL = defaultBaseURL
 	}
+	if config.APIVersion == "" {
+		config.APIVersion = defaultAPIVersion
+	}
 	if config.Timeout == 0 {
 		config.Timeout = defaultTimeout
 	}
-	if config.Language == "" {
-		config.Language = "uk"
+	if config.CacheTTL == 0 {
+		config.CacheTTL = defaultCacheTTL
+	}
+	if config.RateCacheTTL == 0 {
+		config.RateCacheTTL = defaultRateCacheTTL
+	}
+	if config.RetryWait == 0 {
+		config.RetryWait = defaultRetryWait
+	}
+	if config.RetryMaxDelay == 0 {
+		config.RetryMaxDelay = defaultRetryMaxDelay
+	}
+	if config.RedactFields == nil {
+		config.RedactFields = DefaultRedactFields
+	}
+	if config.RetryPolicy == nil {
+		config.RetryPolicy = DefaultRetryPolicy
+	}
+	if config.RetryJitter == "" {
+		config.RetryJitter = JitterFull
+	}
+	if config.AuthHeaderName == "" {
+		config.AuthHeaderName = defaultAuthHeaderName
+	}
+	if config.RequestIDHeader == "" {
+		config.RequestIDHeader = defaultRequestIDHeader
+	}
+	if config.KeyProvider == nil {
+		config.KeyProvider = StaticKey(config.APIKey)
+	}
+	if config.JSON == nil {
+		config.JSON = stdJSON{}
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+	language := defaultLanguage
+	if config.Language != "" {
+		lang, err := ParseLanguage(config.Language)
+		if err != nil {
+			config.Logger.Warn("lardiAPI: unknown language, falling back to default",
+				"language", config.Language, "default", defaultLanguage)
+		} else {
+			language = lang
+		}
+	}
+	config.Language = language.String()
+	if config.FallbackLanguage != "" {
+		lang, err := ParseLanguage(string(config.FallbackLanguage))
+		if err != nil {
+			config.Logger.Warn("lardiAPI: unknown fallback language, disabling fallback",
+				"language", config.FallbackLanguage)
+		}
+		config.FallbackLanguage = lang
 	}
 
-	return &Client{
-		config: config,
-		http: &http.Client{
-			Timeout: config.Timeout,
-		},
+	httpClient := config.HTTPClient
+	if config.Offline {
+		base, err := url.Parse(config.BaseURL)
+		if err != nil {
+			base = &url.URL{}
+		}
+		httpClient = &offlineHTTP{basePath: strings.TrimRight(base.Path, "/")}
 	}
+	if httpClient == nil {
+		if config.MaxIdleConns == 0 {
+			config.MaxIdleConns = defaultMaxIdleConns
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConns = config.MaxIdleConns
+		// All requests go to a single host, so it may keep every idle connection
+		transport.MaxIdleConnsPerHost = config.MaxIdleConns
+		transport.MaxConnsPerHost = config.MaxConnsPerHost
+		httpClient = &http.Client{
+			Timeout:   config.Timeout,
+			Transport: transport,
+		}
+	}
+
+	c := &Client{
+		config:   config,
+		language: language,
+		http:     httpClient,
+		cache:    newReferenceCache(config.CacheTTL),
+	}
+	if config.MaxConcurrentRequests > 0 {
+		c.slots = newSlotQueue(config.MaxConcurrentRequests)
+	}
+	return c
 }
 
 type ResponseContacts struct {
 	ContactID   int    `json:"contactId"`
 	ContactName string `json:"face"`
+	Type        string `json:"type"`
 }
 
 // Response represents a generic API response
@@ -84,16 +342,28 @@ type Response struct {
 	Name string `json:"name"`
 }
 
-// CargoRequest represents the request body for creating a cargo proposal
+// CargoRequest represents the request body for creating a cargo proposal.
+// ContactPhone and ContactFace optionally replace the phone and name of the
+// contact carriers should call about this proposal; the phone must be an
+// international number such as "+380 67 123 45 67".
+// It is not safe for concurrent mutation: build it in one goroutine (or with
+// CargoBuilder) before sharing it. Validate and the client methods only read
+// the request, so concurrent calls on an unchanging request are safe.
 type CargoRequest struct {
-	ContactID          int           `json:"contactId,omitempty"`
-	DateFrom           string        `json:"dateFrom,omitempty" validate:"required"`
-	DateTo             string        `json:"dateTo,omitempty"`
-	PaymentValue       int           `json:"paymentValue,omitempty"`
-	PaymentCurrencyID  int           `json:"paymentCurrencyId,omitempty"`
-	PaymentUnitID      int           `json:"paymentUnitId,omitempty"`
-	PaymentMomentID    int           `json:"paymentMomentId,omitempty"`
-	CargoBodyTypeIDs   []int         `json:"cargoBodyTypeIds,omitempty" validate:"required"`
+	ContactID         int    `json:"contactId,omitempty"`
+	BranchID          int    `json:"branchId,omitempty"`
+	ContactPhone      string `json:"contactPhone,omitempty"`
+	ContactFace       string `json:"contactFace,omitempty"`
+	DateFrom          string `json:"dateFrom,omitempty" validate:"required"`
+	DateTo            string `json:"dateTo,omitempty"`
+	PaymentValue      int    `json:"paymentValue,omitempty"`
+	PaymentCurrencyID int    `json:"paymentCurrencyId,omitempty"`
+	PaymentUnitID     int    `json:"paymentUnitId,omitempty"`
+	PaymentMomentID   int    `json:"paymentMomentId,omitempty"`
+	CargoBodyTypeIDs  []int  `json:"cargoBodyTypeIds,omitempty" validate:"required"`
+	// CargoBodyTypeMatch tells whether a vehicle needs any or all of
+	// CargoBodyTypeIDs. Empty means BodyTypeAny.
+	CargoBodyTypeMatch BodyTypeMatch `json:"cargoBodyTypeMatch,omitempty" validate:"omitempty,oneof=any all"`
 	CargoPackaging     []CargoPack   `json:"cargoPackaging,omitempty"`
 	PaymentForms       []PaymentForm `json:"paymentForms,omitempty"`
 	LorryAmount        int           `json:"lorryAmount,omitempty"`
@@ -123,6 +393,10 @@ type LoadParams struct {
 	CountrySign string   `json:"countrySign"`
 	RegionID    int      `json:"regionId"`
 	PostCodes   []string `json:"postCode"`
+	// SizeMass and SizeVolume optionally give the quantity loaded or
+	// unloaded at this waypoint for multi-stop routes
+	SizeMass   float64 `json:"sizeMass,omitempty"`
+	SizeVolume float64 `json:"sizeVolume,omitempty"`
 }
 
 type Request struct {
@@ -140,47 +414,73 @@ type DeleteCargo struct {
 // CargoResponse represents the response from creating a cargo proposal
 type CargoResponse struct {
 	ID int `json:"id"`
-}
-
-// APIError represents an error response from the API
-type APIError struct {
-	Status  int    `json:"status"`
-	Err     string `json:"error"`
-	Message string `json:"message"`
-}
-
-func (e *APIError) Error() string {
-	return fmt.Sprintf("API error: status=%d, error=%s, message=%s", e.Status, e.Err, e.Message)
+	// URL is the web page of the proposal on the platform, if the API sent it
+	URL string `json:"url,omitempty"`
+	// Warnings are advisory messages about an accepted proposal,
+	// e.g. a price below the market level
+	Warnings []string `json:"warnings,omitempty"`
+	// Cargo is the created proposal, set when the client is configured
+	// with PreferRepresentation: RepresentationFull
+	Cargo *Cargo `json:"-"`
 }
 
 // CreateCargo creates a new cargo proposal
-func (c *Client) CreateCargo(ctx context.Context, req *CargoRequest) (*CargoResponse, error) {
-	validate := validator.New()
-	err := validate.Struct(req)
+func (c *Client) CreateCargo(
+	ctx context.Context, req *CargoRequest, opts ...RequestOption,
+) (*CargoResponse, error) {
+	err := req.Validate()
 	if err != nil {
-		validationErrors, ok := err.(validator.ValidationErrors)
-		if !ok {
-			return nil, fmt.Errorf("unexpected validation error: %w", err)
-		}
-		return nil, validationErrors
+		return nil, err
 	}
+	c.warnCargo(req)
+	req = c.withDefaults(req)
 
 	var resp CargoResponse
-	err = c.post(ctx, pathCargo, req, &resp)
+	if c.config.DedupeOnRetry {
+		// Look for the cargo in the same account
+		var keyOpts []RequestOption
+		if key := newRequestOptions(opts).apiKey; key != "" {
+			keyOpts = append(keyOpts, WithAPIKey(key))
+		}
+		ctx = withRetryCheck(ctx, func(ctx context.Context) (bool, error) {
+			cargo, err := c.findCreatedCargo(ctx, req, keyOpts...)
+			if err != nil || cargo == nil {
+				return false, err
+			}
+			resp.ID = cargo.ID
+			return true, nil
+		})
+	}
+	var body json.RawMessage
+	err = c.post(ctx, pathCargo, req, &body, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("create cargo request failed: %w", err)
 	}
+	if len(body) > 0 {
+		if err := c.decode(body, &resp, "response"); err != nil {
+			return nil, err
+		}
+		if c.config.PreferRepresentation == RepresentationFull {
+			var cargo Cargo
+			if err := c.decode(body, &cargo, "response"); err != nil {
+				return nil, err
+			}
+			resp.Cargo = &cargo
+		}
+	}
 
 	return &resp, nil
 }
 
-func (c *Client) DeleteCargo(ctx context.Context, id int) (*DeleteResponse, error) {
+func (c *Client) DeleteCargo(
+	ctx context.Context, id int, opts ...RequestOption,
+) (*DeleteResponse, error) {
 	deletes := DeleteCargo{
 		CargoIds: []int{id},
 	}
 
 	var resp DeleteResponse
-	err := c.post(ctx, pathDelete, deletes, &resp)
+	err := c.post(ctx, pathDelete, deletes, &resp, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("delete cargo request failed: %w", err)
 	}
@@ -188,21 +488,18 @@ func (c *Client) DeleteCargo(ctx context.Context, id int) (*DeleteResponse, erro
 	return &resp, nil
 }
 
-func (c *Client) UpdateCargo(ctx context.Context, id int, status string, req *CargoRequest) (
-	*CargoResponse, error,
-) {
-	validate := validator.New()
-	err := validate.Struct(req)
+func (c *Client) UpdateCargo(
+	ctx context.Context, id int, status string, req *CargoRequest, opts ...RequestOption,
+) (*CargoResponse, error) {
+	err := req.Validate()
 	if err != nil {
-		validationErrors, ok := err.(validator.ValidationErrors)
-		if !ok {
-			return nil, fmt.Errorf("unexpected validation error: %w", err)
-		}
-		return nil, validationErrors
+		return nil, err
 	}
+	c.warnCargo(req)
+	req = c.withDefaults(req)
 	path := fmt.Sprintf(pathUpdate, status, id)
 	var resp CargoResponse
-	err = c.put(ctx, path, req, &resp)
+	err = c.put(ctx, path, req, &resp, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("update cargo request failed: %w", err)
 	}
@@ -210,37 +507,47 @@ func (c *Client) UpdateCargo(ctx context.Context, id int, status string, req *Ca
 }
 
 // GetContacts retrieves available contacts
-func (c *Client) GetContacts(ctx context.Context) ([]ResponseContacts, error) {
+func (c *Client) GetContacts(ctx context.Context, opts ...RequestOption) ([]ResponseContacts, error) {
 	var resp []ResponseContacts
-	err := c.get(ctx, pathContacts, &resp)
+	err := c.get(ctx, pathContacts, &resp, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("get contacts failed: %w", err)
 	}
 	return resp, nil
 }
 
+// GetContactsByType retrieves the contacts of the given type, e.g. dispatchers.
+// An unknown type yields an empty result.
+func (c *Client) GetContactsByType(
+	ctx context.Context, contactType string, opts ...RequestOption,
+) ([]ResponseContacts, error) {
+	contacts, err := c.GetContacts(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var resp []ResponseContacts
+	for _, v := range contacts {
+		if v.Type == contactType {
+			resp = append(resp, v)
+		}
+	}
+	return resp, nil
+}
+
 // GetAreas retrieves available areas
-func (c *Client) GetAreas(ctx context.Context, area Request) (*Response, error) {
+func (c *Client) GetAreas(ctx context.Context, area Request, opts ...RequestOption) (*Response, error) {
 	var resp []Response
-	err := c.get(ctx, pathAreas, &resp)
+	err := c.getCached(ctx, pathAreas, &resp, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("get areas failed: %w", err)
 	}
-	for _, v := range resp {
-		if v.Name == area.Name {
-			return &Response{
-				ID:   v.ID,
-				Name: v.Name,
-			}, nil
-		}
-	}
-	return nil, nil
+	return findByName(resp, area.Name, c.config.TransliterateNames), nil
 }
 
 // GetLoadTypes retrieves available load types
-func (c *Client) GetLoadTypes(ctx context.Context) ([]Response, error) {
+func (c *Client) GetLoadTypes(ctx context.Context, opts ...RequestOption) ([]Response, error) {
 	var resp []Response
-	err := c.get(ctx, pathLoadTypes, &resp)
+	err := c.getCached(ctx, pathLoadTypes, &resp, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("get load types failed: %w", err)
 	}
@@ -248,19 +555,30 @@ func (c *Client) GetLoadTypes(ctx context.Context) ([]Response, error) {
 }
 
 // GetPaymentTypes retrieves available payment types
-func (c *Client) GetPaymentTypes(ctx context.Context) ([]Response, error) {
+func (c *Client) GetPaymentTypes(ctx context.Context, opts ...RequestOption) ([]Response, error) {
 	var resp []Response
-	err := c.get(ctx, pathTypesPayment, &resp)
+	err := c.getCached(ctx, pathTypesPayment, &resp, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("get payment types failed: %w", err)
 	}
 	return resp, nil
 }
 
+// GetPaymentForms retrieves available payment forms, whose IDs are used in
+// CargoRequest.PaymentForms
+func (c *Client) GetPaymentForms(ctx context.Context, opts ...RequestOption) ([]Response, error) {
+	var resp []Response
+	err := c.getCached(ctx, pathPaymentForms, &resp, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("get payment forms failed: %w", err)
+	}
+	return resp, nil
+}
+
 // GetPackageTypes retrieves available package types
-func (c *Client) GetPackageTypes(ctx context.Context) ([]Response, error) {
+func (c *Client) GetPackageTypes(ctx context.Context, opts ...RequestOption) ([]Response, error) {
 	var resp []Response
-	err := c.get(ctx, pathPackage, &resp)
+	err := c.getCached(ctx, pathPackage, &resp, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("get package types failed: %w", err)
 	}
@@ -268,28 +586,21 @@ func (c *Client) GetPackageTypes(ctx context.Context) ([]Response, error) {
 }
 
 // GetBodyTypes retrieves available body types
-func (c *Client) GetBodyTypes(ctx context.Context, body Request) (*Response, error) {
+func (c *Client) GetBodyTypes(
+	ctx context.Context, body Request, opts ...RequestOption,
+) (*Response, error) {
 	var resp []Response
-	err := c.get(ctx, pathTypes, &resp)
+	err := c.getCached(ctx, pathTypes, &resp, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("get body types failed: %w", err)
 	}
-	for _, v := range resp {
-		if body.Name == v.Name {
-			return &Response{
-				ID:   v.ID,
-				Name: v.Name,
-			}, nil
-		}
-	}
-
-	return nil, nil
+	return findByName(resp, body.Name, c.config.TransliterateNames), nil
 }
 
 // GetPaymentMoments retrieves available payment moments
-func (c *Client) GetPaymentMoments(ctx context.Context) ([]Response, error) {
+func (c *Client) GetPaymentMoments(ctx context.Context, opts ...RequestOption) ([]Response, error) {
 	var resp []Response
-	err := c.get(ctx, pathMoments, &resp)
+	err := c.getCached(ctx, pathMoments, &resp, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("get payment moments failed: %w", err)
 	}
@@ -297,106 +608,364 @@ func (c *Client) GetPaymentMoments(ctx context.Context) ([]Response, error) {
 }
 
 // GetCurrencies retrieves available currencies
-func (c *Client) GetCurrencies(ctx context.Context, currency Request) (*Response, error) {
+func (c *Client) GetCurrencies(
+	ctx context.Context, currency Request, opts ...RequestOption,
+) (*Response, error) {
 	var resp []Response
-	err := c.get(ctx, pathCurrencies, &resp)
+	err := c.getCached(ctx, pathCurrencies, &resp, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("get currencies failed: %w", err)
 	}
-	for _, v := range resp {
-		if v.Name == currency.Name {
-			return &Response{
-				ID:   v.ID,
-				Name: v.Name,
-			}, nil
-		}
-	}
-
-	return nil, nil
+	return findByName(resp, currency.Name, c.config.TransliterateNames), nil
 }
 
-// GetUnits retrieves available units
-func (c *Client) GetUnits(ctx context.Context) ([]Response, error) {
+// GetUnits retrieves available units. The list is cached per language.
+func (c *Client) GetUnits(ctx context.Context, opts ...RequestOption) ([]Response, error) {
 	var resp []Response
-	err := c.get(ctx, pathUnits, &resp)
+	err := c.getCached(ctx, pathUnits, &resp, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("get units failed: %w", err)
 	}
 	return resp, nil
 }
 
+// encodeBody returns the bytes of a request body. An io.Reader or []byte is
+// sent as is, e.g. with WithContentType for non-JSON bodies; anything else
+// is encoded as JSON.
+func (c *Client) encodeBody(body interface{}) ([]byte, error) {
+	switch b := body.(type) {
+	case []byte:
+		return b, nil
+	case io.Reader:
+		data, err := io.ReadAll(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		return data, nil
+	}
+	data, err := c.config.JSON.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	if c.config.PrettyPrintRequests {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, data, "", "  "); err == nil {
+			data = indented.Bytes()
+		}
+	}
+	return data, nil
+}
+
 // post performs a POST request
 func (c *Client) post(
-	ctx context.Context, path string, body interface{}, result interface{},
+	ctx context.Context, path string, body interface{}, result interface{}, opts ...RequestOption,
 ) error {
-	jsonData, err := json.Marshal(body)
+	data, err := c.encodeBody(body)
+	if err != nil {
+		return err
+	}
+
+	o := newRequestOptions(opts)
+	endpoint, err := c.url(path, o)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request body: %w", err)
+		return err
 	}
 
 	req, err := http.NewRequestWithContext(
-		ctx, http.MethodPost, c.config.BaseURL+path, bytes.NewBuffer(jsonData),
+		ctx, http.MethodPost, endpoint, bytes.NewReader(data),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	return c.doRequest(req, result)
+	return c.doRequest(req, result, o)
 }
 
 // get performs a GET request
-func (c *Client) get(ctx context.Context, path string, result interface{}) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.config.BaseURL+path, nil)
+func (c *Client) get(
+	ctx context.Context, path string, result interface{}, opts ...RequestOption,
+) error {
+	o := newRequestOptions(opts)
+	endpoint, err := c.url(path, o)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	return c.doRequest(req, result)
+	return c.doRequest(req, result, o)
 }
 
 // put performs a PUT request
-func (c *Client) put(ctx context.Context, path string, body interface{}, result interface{}) error {
-	jsonData, err := json.Marshal(body)
+func (c *Client) put(
+	ctx context.Context, path string, body interface{}, result interface{}, opts ...RequestOption,
+) error {
+	data, err := c.encodeBody(body)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request body: %w", err)
+		return err
+	}
+
+	o := newRequestOptions(opts)
+	endpoint, err := c.url(path, o)
+	if err != nil {
+		return err
 	}
 
 	req, err := http.NewRequestWithContext(
-		ctx, http.MethodPut, c.config.BaseURL+path, bytes.NewBuffer(jsonData),
+		ctx, http.MethodPut, endpoint, bytes.NewReader(data),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	return c.doRequest(req, result)
+	return c.doRequest(req, result, o)
+}
+
+// delete performs a DELETE request
+func (c *Client) delete(
+	ctx context.Context, path string, result interface{}, opts ...RequestOption,
+) error {
+	o := newRequestOptions(opts)
+	endpoint, err := c.url(path, o)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	return c.doRequest(req, result, o)
+}
+
+// url joins BaseURL, the API version and an endpoint path, which may carry
+// a query string. BaseURL may contain its own path prefix, e.g. when the API
+// is served behind a gateway, with or without a trailing slash.
+func (c *Client) url(path string, o *requestOptions) (string, error) {
+	p, rawQuery, _ := strings.Cut(path, "?")
+	u, err := url.JoinPath(c.config.BaseURL, c.apiVersion(o), p)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL: %w", err)
+	}
+	if rawQuery != "" {
+		u += "?" + rawQuery
+	}
+	return u, nil
+}
+
+// apiVersion returns the API version of a call
+func (c *Client) apiVersion(o *requestOptions) string {
+	if o.apiVersion != "" {
+		return o.apiVersion
+	}
+	return c.config.APIVersion
+}
+
+// doRequest performs the HTTP request and handles the response.
+// A nil result discards the response body.
+func (c *Client) doRequest(req *http.Request, result interface{}, o *requestOptions) error {
+	if !o.deadline.IsZero() {
+		ctx, cancel := context.WithDeadline(req.Context(), o.deadline)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+	if timeout, ok := c.config.TimeoutOverrides[category(req)]; ok && timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	switch {
+	case o.contentType != "":
+		req.Header.Set("Content-Type", o.contentType)
+	case req.Header.Get("Content-Type") == "":
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if id := c.requestID(req.Context()); id != "" {
+		req.Header.Set(c.config.RequestIDHeader, id)
+	}
+	if c.config.PreferRepresentation != "" &&
+		(req.Method == http.MethodPost || req.Method == http.MethodPut) {
+		req.Header.Set("Prefer", c.config.PreferRepresentation.preference())
+	}
+	for key, values := range o.header {
+		req.Header[key] = values
+	}
+
+	if !o.noLanguage || len(o.query) > 0 {
+		q := req.URL.Query()
+		if !o.noLanguage && !o.query.Has("language") {
+			lang := c.language
+			if o.language != "" {
+				lang = o.language
+			}
+			q.Add("language", lang.String())
+		}
+		for key, values := range o.query {
+			for _, v := range values {
+				q.Add(key, v)
+			}
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+	if o.urlRef != nil {
+		*o.urlRef = *req.URL
+	}
+
+	if o.timing != nil {
+		*o.timing = Timing{}
+		start := time.Now()
+		defer func() {
+			o.timing.Total = time.Since(start)
+		}()
+	}
+
+	maxRetries := c.maxRetries(req, o)
+	keyRotated := false
+	for attempt := 0; ; attempt++ {
+		retryable, err := c.sendWithKey(req, result, o, attempt > 0 || keyRotated)
+		if err != nil && c.config.RetryUnauthorized && o.apiKey == "" && !keyRotated &&
+			errors.Is(err, ErrUnauthorized) {
+			// Try once more with the next key from the provider
+			keyRotated = true
+			retryable, err = c.sendWithKey(req, result, o, true)
+		}
+		if err == nil || !retryable || attempt >= maxRetries {
+			return err
+		}
+		retry, applied, checkErr := c.canRetry(req)
+		switch {
+		case checkErr != nil:
+			return checkErr
+		case applied:
+			return nil
+		case !retry:
+			return err
+		}
+		wait := c.backoff(attempt)
+		if c.config.OnRetry != nil {
+			c.config.OnRetry(attempt+1, err, wait)
+		}
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return err
+		}
+	}
 }
 
-// doRequest performs the HTTP request and handles the response
-func (c *Client) doRequest(req *http.Request, result interface{}) error {
-	req.Header.Set("Authorization", c.config.APIKey)
-	req.Header.Set("Content-Type", "application/json")
+// sendWithKey sets the API key from the key provider and sends the request.
+// resend rewinds the body of a request that has already been sent.
+func (c *Client) sendWithKey(
+	req *http.Request, result interface{}, o *requestOptions, resend bool,
+) (bool, error) {
+	if resend && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return false, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		req.Body = body
+	}
 
-	q := req.URL.Query()
-	q.Add("language", c.config.Language)
-	req.URL.RawQuery = q.Encode()
+	key := o.apiKey
+	if key == "" {
+		var err error
+		key, err = c.config.KeyProvider.Key(req.Context())
+		if err != nil {
+			return false, fmt.Errorf("failed to get API key: %w", err)
+		}
+	}
+	req.Header.Set(c.config.AuthHeaderName, key)
 
+	release, err := c.acquireSlot(req.Context(), o.priority)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+	notifySent(req.Context())
+
+	if o.timing != nil {
+		start := time.Now()
+		defer func() {
+			o.timing.Attempts = append(o.timing.Attempts, time.Since(start))
+		}()
+	}
+	return c.send(req, result, o)
+}
+
+// send performs a single attempt of the request. It reports whether a failed
+// attempt may be retried.
+func (c *Client) send(req *http.Request, result interface{}, o *requestOptions) (bool, error) {
 	resp, err := c.http.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return req.Context().Err() == nil, &NetworkError{Err: err}
 	}
 	defer resp.Body.Close()
 
+	c.checkDeprecation(req, resp)
+	c.checkClock(resp)
+	if o.onResponse != nil {
+		o.onResponse(resp)
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		return false, errNotModified
+	}
+
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		retryable := resp.StatusCode == http.StatusTooManyRequests ||
+			resp.StatusCode >= http.StatusInternalServerError
 		var apiErr APIError
-		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
-			return fmt.Errorf("failed to decode error response: %w", err)
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return retryable, fmt.Errorf("failed to read error response: %w", err)
+		}
+		c.logExchange(req, resp.StatusCode, data)
+		if resp.StatusCode == http.StatusServiceUnavailable && isMaintenance(resp.Header, data) {
+			// Maintenance pages are not always JSON
+			_ = c.config.JSON.Unmarshal(data, &apiErr)
+			apiErr.Status = resp.StatusCode
+			return false, &MaintenanceError{APIError: apiErr, Until: maintenanceEnd(resp.Header, time.Now())}
+		}
+		if err := c.config.JSON.Unmarshal(data, &apiErr); err != nil {
+			return retryable, fmt.Errorf("failed to decode error response: %w", err)
+		}
+		if apiErr.Status == 0 {
+			apiErr.Status = resp.StatusCode
 		}
-		return &apiErr
+		retryable = c.config.RetryPolicy(&apiErr)
+		if resp.StatusCode == http.StatusBadRequest {
+			return retryable, &ValidationError{APIError: apiErr}
+		}
+		return retryable, &apiErr
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	if result == nil {
+		return false, nil
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, fmt.Errorf("failed to read response: %w", err)
+	}
+	c.logExchange(req, resp.StatusCode, data)
+	if err := c.decode(data, result, "response"); err != nil {
+		return false, err
 	}
 
-	return nil
+	return false, nil
+}
+
+// requestID extracts the request ID stored in ctx under RequestIDContextKey
+func (c *Client) requestID(ctx context.Context) string {
+	if c.config.RequestIDContextKey == nil {
+		return ""
+	}
+	switch v := ctx.Value(c.config.RequestIDContextKey).(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	}
+	return ""
 }
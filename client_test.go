@@ -0,0 +1,75 @@
+package lardiAPI
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClientURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		path    string
+		want    string
+	}{
+		{
+			name:    "default base",
+			baseURL: "",
+			path:    "/references/currencies",
+			want:    "https://api.lardi-trans.com/v2/references/currencies",
+		},
+		{
+			name:    "gateway subpath",
+			baseURL: "https://gw.internal/lardi",
+			path:    "/references/currencies",
+			want:    "https://gw.internal/lardi/v2/references/currencies",
+		},
+		{
+			name:    "gateway subpath with trailing slash",
+			baseURL: "https://gw.internal/lardi/",
+			path:    "/references/currencies",
+			want:    "https://gw.internal/lardi/v2/references/currencies",
+		},
+		{
+			name:    "gateway subpath with several trailing slashes",
+			baseURL: "https://gw.internal/lardi//",
+			path:    "/references/currencies",
+			want:    "https://gw.internal/lardi/v2/references/currencies",
+		},
+		{
+			name:    "path with query",
+			baseURL: "https://gw.internal/lardi",
+			path:    "/proposals/my/cargoes?page=2&size=50",
+			want:    "https://gw.internal/lardi/v2/proposals/my/cargoes?page=2&size=50",
+		},
+		{
+			name:    "trailing slash and path with query",
+			baseURL: "https://gw.internal/lardi/",
+			path:    "/references/areas?countrySign=UA",
+			want:    "https://gw.internal/lardi/v2/references/areas?countrySign=UA",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient(Config{BaseURL: tt.baseURL})
+			got, err := c.url(tt.path, newRequestOptions(nil))
+			if err != nil {
+				t.Fatalf("url(%q): %v", tt.path, err)
+			}
+			if got != tt.want {
+				t.Errorf("url(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOfflineTrailingSlashBaseURL(t *testing.T) {
+	c := NewClient(Config{BaseURL: "https://gw.internal/lardi//", Offline: true})
+	currency, err := c.GetCurrencies(context.Background(), Request{Name: "stub"})
+	if err != nil {
+		t.Fatalf("GetCurrencies: %v", err)
+	}
+	if currency == nil || currency.ID != 1 {
+		t.Errorf("GetCurrencies = %+v, want the offline stub", currency)
+	}
+}
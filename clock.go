@@ -0,0 +1,53 @@
+package lardiAPI
+
+import (
+	"net/http"
+	"time"
+)
+
+// maxClockSkew is the clock difference to the API above which a warning is
+// logged, since dates computed from the local clock may then be rejected
+const maxClockSkew = time.Minute
+
+// ClockSkew returns how far the API server's clock is ahead of the local
+// clock, as measured from the Date header of the last response. It is zero
+// before the first response. The header has a resolution of one second.
+func (c *Client) ClockSkew() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.clockSkew
+}
+
+// ServerNow returns the current time corrected by ClockSkew, e.g. for
+// computing the dates of a proposal with SetCargoDates on a machine whose
+// clock drifted
+func (c *Client) ServerNow() time.Time {
+	return time.Now().Add(c.ClockSkew())
+}
+
+// checkClock measures the clock skew from the Date header of resp and warns
+// the first time it exceeds maxClockSkew
+func (c *Client) checkClock(resp *http.Response) {
+	date := resp.Header.Get("Date")
+	if date == "" {
+		return
+	}
+	server, err := http.ParseTime(date)
+	if err != nil {
+		return
+	}
+	skew := server.Sub(time.Now()).Round(time.Second)
+
+	c.mu.Lock()
+	c.clockSkew = skew
+	warn := !c.skewWarned && (skew > maxClockSkew || skew < -maxClockSkew)
+	if warn {
+		c.skewWarned = true
+	}
+	c.mu.Unlock()
+
+	if warn {
+		c.config.Logger.Warn("lardiAPI: local clock differs from the API server",
+			"skew", skew)
+	}
+}
@@ -0,0 +1,57 @@
+package lardiAPI
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// JSONCodec marshals request bodies and unmarshals responses. It allows
+// replacing encoding/json with a faster implementation such as jsoniter
+// (jsoniter.ConfigCompatibleWithStandardLibrary satisfies it).
+type JSONCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// stdJSON is the default JSONCodec backed by encoding/json
+type stdJSON struct{}
+
+func (stdJSON) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (stdJSON) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// DecodeResponse decodes a response body, e.g. one captured from the API,
+// into v with the codec used for real responses. It helps to diagnose field
+// tag mismatches and schema changes offline.
+func (c *Client) DecodeResponse(data []byte, v interface{}) error {
+	return c.decode(data, v, "response")
+}
+
+// maxBodySnippet limits the part of a body quoted in decode errors
+const maxBodySnippet = 64
+
+// decode unmarshals data into v. Errors name the type of v and quote the
+// start of the redacted data, so that a body of an unexpected shape, e.g. an
+// object where a list was expected, is easy to spot.
+func (c *Client) decode(data []byte, v interface{}, what string) error {
+	if err := c.config.JSON.Unmarshal(data, v); err != nil {
+		// Bodies that are not JSON cannot be redacted, so only their size
+		// is reported, as in the logs
+		snippet := loggedBody(data, c.config.RedactFields)
+		if len(snippet) > maxBodySnippet {
+			// Cut on a rune boundary, as names are mostly Cyrillic
+			cut := maxBodySnippet
+			for cut > 0 && !utf8.RuneStart(snippet[cut]) {
+				cut--
+			}
+			snippet = snippet[:cut] + "..."
+		}
+		return fmt.Errorf("failed to decode %s into %T: %w (body: %q)", what, v, err, snippet)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package lardiAPI
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestDecodeErrorSnippetCyrillic(t *testing.T) {
+	c := NewClient(Config{})
+	// An object where a list is expected, with names long enough to be cut
+	body := `{"id":12,"name":"` + strings.Repeat("Київська область ", 8) + `"}`
+	var list []Response
+	err := c.decode([]byte(body), &list, "response")
+	if err == nil {
+		t.Fatal("decode = nil, want error")
+	}
+	msg := err.Error()
+	if !utf8.ValidString(msg) || strings.Contains(msg, `\x`) {
+		t.Errorf("error splits a rune: %s", msg)
+	}
+	if !strings.Contains(msg, `{\"id\":12,\"name\":\"Київська`) || !strings.Contains(msg, `...`) {
+		t.Errorf("error does not quote the truncated body: %s", msg)
+	}
+}
+
+func TestDecodeErrorNonJSONBody(t *testing.T) {
+	c := NewClient(Config{})
+	body := []byte(`<html>contactPhone: +380671234567</html>`)
+	var list []Response
+	err := c.decode(body, &list, "response")
+	if err == nil {
+		t.Fatal("decode = nil, want error")
+	}
+	if msg := err.Error(); strings.Contains(msg, "380671234567") || !strings.Contains(msg, fmt.Sprintf("[%d bytes]", len(body))) {
+		t.Errorf("error quotes the unredacted body: %s", msg)
+	}
+}
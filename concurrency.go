@@ -0,0 +1,113 @@
+package lardiAPI
+
+import (
+	"context"
+	"sync"
+)
+
+// Priority orders requests waiting for a slot when
+// Config.MaxConcurrentRequests is set
+type Priority int
+
+// Request priorities. Waiting requests of a higher priority are sent first;
+// requests of the same priority are sent in the order they arrived.
+const (
+	PriorityLow    Priority = -1
+	PriorityNormal Priority = 0
+	PriorityHigh   Priority = 1
+)
+
+// WithPriority sets the priority of the call, e.g. PriorityHigh for
+// interactive requests sharing the client with background batches.
+// It only matters when Config.MaxConcurrentRequests is set.
+func WithPriority(p Priority) RequestOption {
+	return func(o *requestOptions) {
+		o.priority = p
+	}
+}
+
+// slotQueue limits the number of requests in flight and hands freed slots to
+// the waiting requests of the highest priority first
+type slotQueue struct {
+	mu   sync.Mutex
+	free int
+	// waiting holds the queues of high, normal and low priority requests
+	waiting [3][]chan struct{}
+}
+
+func newSlotQueue(n int) *slotQueue {
+	return &slotQueue{free: n}
+}
+
+func queueIndex(p Priority) int {
+	switch {
+	case p > PriorityNormal:
+		return 0
+	case p < PriorityNormal:
+		return 2
+	}
+	return 1
+}
+
+// acquire waits for a free slot. It returns the context error if ctx is done
+// first.
+func (q *slotQueue) acquire(ctx context.Context, p Priority) error {
+	q.mu.Lock()
+	if q.free > 0 {
+		q.free--
+		q.mu.Unlock()
+		return nil
+	}
+	granted := make(chan struct{})
+	i := queueIndex(p)
+	q.waiting[i] = append(q.waiting[i], granted)
+	q.mu.Unlock()
+
+	select {
+	case <-granted:
+		return nil
+	case <-ctx.Done():
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		for j, ch := range q.waiting[i] {
+			if ch == granted {
+				q.waiting[i] = append(q.waiting[i][:j], q.waiting[i][j+1:]...)
+				return ctx.Err()
+			}
+		}
+		// The slot was granted while the context was done; pass it on
+		q.releaseLocked()
+		return ctx.Err()
+	}
+}
+
+// release frees a slot, handing it to the next waiting request if any
+func (q *slotQueue) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.releaseLocked()
+}
+
+func (q *slotQueue) releaseLocked() {
+	for i := range q.waiting {
+		if len(q.waiting[i]) > 0 {
+			close(q.waiting[i][0])
+			q.waiting[i] = q.waiting[i][1:]
+			return
+		}
+	}
+	q.free++
+}
+
+// acquireSlot waits until fewer than Config.MaxConcurrentRequests requests
+// are in flight and returns the function that frees the slot. It returns
+// the context error if ctx is done first.
+func (c *Client) acquireSlot(ctx context.Context, p Priority) (func(), error) {
+	if c.slots == nil {
+		return func() {}, nil
+	}
+	if err := c.slots.acquire(ctx, p); err != nil {
+		return nil, err
+	}
+	return c.slots.release, nil
+}
@@ -0,0 +1,38 @@
+package lardiAPI
+
+import "time"
+
+// dateLayout is the format of the dates of cargo proposals
+const dateLayout = "2006-01-02"
+
+// apiTimeZone is the time zone the API interprets the dates of cargo
+// proposals in: dates are calendar days in Ukraine. The fixed offset is
+// used when the time zone database is unavailable.
+var apiTimeZone = loadLocation("Europe/Kyiv", time.FixedZone("EET", 2*60*60))
+
+func loadLocation(name string, fallback *time.Location) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fallback
+	}
+	return loc
+}
+
+// SetDates sets DateFrom and DateTo to the calendar days of from and to in
+// loc, e.g. the time zone of the user who picked them. A nil loc means the
+// time zone of the API. A zero to clears DateTo.
+func (r *CargoRequest) SetDates(from, to time.Time, loc *time.Location) {
+	if loc == nil {
+		loc = apiTimeZone
+	}
+	r.DateFrom = from.In(loc).Format(dateLayout)
+	r.DateTo = ""
+	if !to.IsZero() {
+		r.DateTo = to.In(loc).Format(dateLayout)
+	}
+}
+
+// SetCargoDates sets the dates of req with SetDates in Config.TimeZone
+func (c *Client) SetCargoDates(req *CargoRequest, from, to time.Time) {
+	req.SetDates(from, to, c.config.TimeZone)
+}
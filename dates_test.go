@@ -0,0 +1,76 @@
+package lardiAPI
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetDatesDayBoundary(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	kyiv, err := time.LoadLocation("Europe/Kyiv")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		loc      *time.Location
+		wantFrom string
+		wantTo   string
+	}{
+		{
+			name:     "winter in Kyiv is the next day",
+			from:     time.Date(2024, 11, 10, 23, 30, 0, 0, time.UTC),
+			to:       time.Date(2024, 11, 12, 23, 30, 0, 0, time.UTC),
+			loc:      kyiv,
+			wantFrom: "2024-11-11",
+			wantTo:   "2024-11-13",
+		},
+		{
+			name:     "winter in New York is the same day",
+			from:     time.Date(2024, 11, 10, 23, 30, 0, 0, time.UTC),
+			to:       time.Date(2024, 11, 12, 23, 30, 0, 0, time.UTC),
+			loc:      newYork,
+			wantFrom: "2024-11-10",
+			wantTo:   "2024-11-12",
+		},
+		{
+			name:     "summer in Kyiv is the next day",
+			from:     time.Date(2024, 7, 15, 21, 30, 0, 0, time.UTC),
+			loc:      kyiv,
+			wantFrom: "2024-07-16",
+		},
+		{
+			name:     "summer in New York is the same day",
+			from:     time.Date(2024, 7, 15, 21, 30, 0, 0, time.UTC),
+			loc:      newYork,
+			wantFrom: "2024-07-15",
+		},
+		{
+			name:     "New York evening is the next day in Kyiv",
+			from:     time.Date(2024, 11, 10, 19, 0, 0, 0, newYork),
+			loc:      nil,
+			wantFrom: "2024-11-11",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &CargoRequest{DateTo: "2024-01-01"}
+			r.SetDates(tt.from, tt.to, tt.loc)
+			if r.DateFrom != tt.wantFrom || r.DateTo != tt.wantTo {
+				t.Errorf("got %q - %q, want %q - %q", r.DateFrom, r.DateTo, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+
+	c := NewClient(Config{TimeZone: newYork})
+	r := &CargoRequest{}
+	c.SetCargoDates(r, time.Date(2024, 11, 10, 23, 30, 0, 0, time.UTC), time.Time{})
+	if r.DateFrom != "2024-11-10" {
+		t.Errorf("SetCargoDates in New York: DateFrom = %q, want 2024-11-10", r.DateFrom)
+	}
+}
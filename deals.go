@@ -0,0 +1,78 @@
+package lardiAPI
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Deal is a completed deal on one of the account's cargo proposals
+type Deal struct {
+	ID      int `json:"id"`
+	CargoID int `json:"cargoId"`
+	// Carrier is the carrier the cargo was booked by
+	Carrier            DealCarrier  `json:"carrier"`
+	PaymentValue       int          `json:"paymentValue"`
+	PaymentCurrencyID  int          `json:"paymentCurrencyId"`
+	PaymentUnitID      int          `json:"paymentUnitId"`
+	WaypointListSource []LoadParams `json:"waypointListSource"`
+	WaypointListTarget []LoadParams `json:"waypointListTarget"`
+	// Date is the date the deal was closed, as sent by the API
+	Date string `json:"date"`
+}
+
+// DealCarrier identifies the carrier of a deal
+type DealCarrier struct {
+	ContactID   int    `json:"contactId"`
+	Name        string `json:"name"`
+	CompanyName string `json:"companyName,omitempty"`
+}
+
+// DealFilter selects the deals returned by ListDeals
+type DealFilter struct {
+	// DateFrom and DateTo limit the closing dates of the deals, inclusive,
+	// in the date format of CargoRequest.DateFrom. Empty means no limit.
+	DateFrom string
+	DateTo   string
+	// Page is the page to fetch: a zero PageInfo for the first page and the
+	// result of Next() for the following ones
+	Page PageInfo
+}
+
+func (f DealFilter) query() url.Values {
+	q := url.Values{}
+	if f.DateFrom != "" {
+		q.Set("dateFrom", f.DateFrom)
+	}
+	if f.DateTo != "" {
+		q.Set("dateTo", f.DateTo)
+	}
+	return q
+}
+
+// ListDeals retrieves one page of the account's completed deals, e.g. for
+// revenue reports
+func (c *Client) ListDeals(
+	ctx context.Context, filter DealFilter, opts ...RequestOption,
+) ([]Deal, *PageInfo, error) {
+	var deals []Deal
+	info, err := c.getPage(ctx, pathDeals, filter.query(), filter.Page, &deals, opts...)
+	if err != nil && isRecordErrors(err) {
+		return deals, info, fmt.Errorf("list deals: %w", err)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("list deals failed: %w", err)
+	}
+	return deals, info, nil
+}
+
+// IterateDeals returns an iterator over all of the account's completed deals
+// matching filter, starting from filter.Page
+func (c *Client) IterateDeals(
+	ctx context.Context, filter DealFilter, opts ...RequestOption,
+) *Iterator[Deal] {
+	return newIterator(ctx, filter.Page, func(ctx context.Context, page PageInfo) ([]Deal, *PageInfo, error) {
+		filter.Page = page
+		return c.ListDeals(ctx, filter, opts...)
+	})
+}
@@ -0,0 +1,74 @@
+package lardiAPI
+
+import (
+	"net/http"
+	"time"
+)
+
+// DeprecationNotice describes Deprecation and Sunset headers sent by the API
+type DeprecationNotice struct {
+	Method      string
+	Path        string
+	Deprecation string
+	// Sunset is the time the endpoint is going away, zero if unknown
+	Sunset     time.Time
+	Link       string
+	ReceivedAt time.Time
+}
+
+// LastDeprecationNotice returns the most recent deprecation notice received
+// from the API, or nil if there was none
+func (c *Client) LastDeprecationNotice() *DeprecationNotice {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.deprecation == nil {
+		return nil
+	}
+	notice := *c.deprecation
+	return &notice
+}
+
+// checkDeprecation records and reports Deprecation and Sunset headers. The
+// warning is logged once per method and path, so polling a deprecated
+// endpoint does not flood the log; OnDeprecation sees every notice.
+func (c *Client) checkDeprecation(req *http.Request, resp *http.Response) {
+	deprecation := resp.Header.Get("Deprecation")
+	sunset := resp.Header.Get("Sunset")
+	if deprecation == "" && sunset == "" {
+		return
+	}
+
+	notice := DeprecationNotice{
+		Method:      req.Method,
+		Path:        req.URL.Path,
+		Deprecation: deprecation,
+		Link:        resp.Header.Get("Link"),
+		ReceivedAt:  time.Now(),
+	}
+	if sunset != "" {
+		if t, err := http.ParseTime(sunset); err == nil {
+			notice.Sunset = t
+		}
+	}
+
+	endpoint := notice.Method + " " + notice.Path
+	c.mu.Lock()
+	c.deprecation = &notice
+	warn := !c.deprecationWarned[endpoint]
+	if warn {
+		if c.deprecationWarned == nil {
+			c.deprecationWarned = make(map[string]bool)
+		}
+		c.deprecationWarned[endpoint] = true
+	}
+	c.mu.Unlock()
+
+	if warn {
+		c.config.Logger.Warn("lardiAPI: endpoint is deprecated",
+			"method", notice.Method, "path", notice.Path,
+			"deprecation", notice.Deprecation, "sunset", sunset)
+	}
+	if c.config.OnDeprecation != nil {
+		c.config.OnDeprecation(notice)
+	}
+}
@@ -0,0 +1,43 @@
+package lardiAPI
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDeprecationWarnsOncePerEndpoint(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+	var logs bytes.Buffer
+	notices := 0
+	c := NewClient(Config{
+		BaseURL:       srv.URL,
+		APIKey:        "key",
+		Logger:        slog.New(slog.NewTextHandler(&logs, nil)),
+		OnDeprecation: func(DeprecationNotice) { notices++ },
+	})
+
+	for range 3 {
+		if _, err := c.GetBranches(context.Background()); err != nil {
+			t.Fatalf("GetBranches: %v", err)
+		}
+	}
+	if _, err := c.GetContacts(context.Background()); err != nil {
+		t.Fatalf("GetContacts: %v", err)
+	}
+
+	if n := strings.Count(logs.String(), "endpoint is deprecated"); n != 2 {
+		t.Errorf("logged %d deprecation warnings, want one per endpoint:\n%s", n, logs.String())
+	}
+	if notices != 4 {
+		t.Errorf("OnDeprecation called %d times, want 4", notices)
+	}
+}
@@ -0,0 +1,78 @@
+package lardiAPI
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldChange describes a field that differs between two requests.
+// Field is the JSON path of the field, e.g. "waypointListSource[0].townName".
+// Old or New is nil when a list element was added or removed.
+type FieldChange struct {
+	Field string
+	Old   interface{}
+	New   interface{}
+}
+
+// DiffCargo returns the fields that differ between old and new.
+// Lists of waypoints, packaging and payment forms are compared element by
+// element; lists of IDs are compared as a whole.
+func DiffCargo(old, new *CargoRequest) []FieldChange {
+	if old == nil {
+		old = &CargoRequest{}
+	}
+	if new == nil {
+		new = &CargoRequest{}
+	}
+	var changes []FieldChange
+	diffValues("", reflect.ValueOf(*old), reflect.ValueOf(*new), &changes)
+	return changes
+}
+
+func diffValues(path string, a, b reflect.Value, changes *[]FieldChange) {
+	switch a.Kind() {
+	case reflect.Struct:
+		t := a.Type()
+		for i := 0; i < t.NumField(); i++ {
+			diffValues(joinField(path, jsonName(t.Field(i))), a.Field(i), b.Field(i), changes)
+		}
+	case reflect.Slice:
+		if a.Type().Elem().Kind() != reflect.Struct {
+			if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+				*changes = append(*changes, FieldChange{Field: path, Old: a.Interface(), New: b.Interface()})
+			}
+			return
+		}
+		for i := 0; i < a.Len() || i < b.Len(); i++ {
+			elem := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= b.Len():
+				*changes = append(*changes, FieldChange{Field: elem, Old: a.Index(i).Interface()})
+			case i >= a.Len():
+				*changes = append(*changes, FieldChange{Field: elem, New: b.Index(i).Interface()})
+			default:
+				diffValues(elem, a.Index(i), b.Index(i), changes)
+			}
+		}
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*changes = append(*changes, FieldChange{Field: path, Old: a.Interface(), New: b.Interface()})
+		}
+	}
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return f.Name
+	}
+	return name
+}
+
+func joinField(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
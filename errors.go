@@ -0,0 +1,176 @@
+package lardiAPI
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// statusClientClosedRequest is the non-standard status commonly used for
+// requests abandoned by the caller
+const statusClientClosedRequest = 499
+
+var (
+	// ErrReferenceNotFound is returned when a reference lookup has no match
+	ErrReferenceNotFound = errors.New("reference not found")
+	// ErrNotFound matches API errors with status 404 Not Found
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized matches API errors with status 401 Unauthorized
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// errNotModified is returned for 304 Not Modified on conditional requests
+	errNotModified = errors.New("not modified")
+)
+
+// APIError represents an error response from the API
+type APIError struct {
+	Status  int          `json:"status"`
+	Err     string       `json:"error"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// Error formats the non-empty parts of the error, e.g.
+// "API error: status=400, message=invalid request, fields: dateFrom: required"
+func (e *APIError) Error() string {
+	var parts []string
+	if e.Status != 0 {
+		parts = append(parts, fmt.Sprintf("status=%d", e.Status))
+	}
+	if e.Err != "" {
+		parts = append(parts, "error="+e.Err)
+	}
+	if e.Message != "" {
+		parts = append(parts, "message="+e.Message)
+	}
+	if len(e.Fields) > 0 {
+		fields := make([]string, len(e.Fields))
+		for i, f := range e.Fields {
+			fields[i] = f.Field + ": " + f.Message
+		}
+		parts = append(parts, "fields: "+strings.Join(fields, "; "))
+	}
+	if len(parts) == 0 {
+		return "API error"
+	}
+	return "API error: " + strings.Join(parts, ", ")
+}
+
+// Is allows matching API errors against sentinel errors with errors.Is
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.Status == http.StatusUnauthorized
+	}
+	return false
+}
+
+// NetworkError is returned when a request could not be sent or its response
+// could not be received, as opposed to an error reported by the API
+type NetworkError struct {
+	Err error
+}
+
+func (e *NetworkError) Error() string {
+	return "request failed: " + e.Err.Error()
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// Timeout reports whether the request timed out, either by the HTTP client
+// timeout or by the context deadline
+func (e *NetworkError) Timeout() bool {
+	var netErr net.Error
+	return errors.Is(e.Err, context.DeadlineExceeded) || errors.As(e.Err, &netErr) && netErr.Timeout()
+}
+
+// FieldError describes a single invalid field reported by the API
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when the API rejects a request with
+// 400 Bad Request. It wraps the APIError, so errors.As works for both types.
+type ValidationError struct {
+	APIError
+}
+
+func (e *ValidationError) Error() string {
+	return e.APIError.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return &e.APIError
+}
+
+// FieldErrors returns the server messages keyed by field name
+func (e *ValidationError) FieldErrors() map[string]string {
+	fields := make(map[string]string, len(e.Fields))
+	for _, f := range e.Fields {
+		fields[f.Field] = f.Message
+	}
+	return fields
+}
+
+// HTTPStatus maps an error returned by the client to the status a service
+// built on top of it may respond with:
+//
+//   - nil: 200
+//   - invalid requests (validator.ValidationErrors and ErrInvalidRequest from
+//     Validate, ValidationError, ErrReferenceNotFound): 400
+//   - ErrUnauthorized: 401, ErrNotFound: 404, ErrAlreadyBooked: 409
+//   - ErrAttachmentTooLarge: 413
+//   - ErrMaintenance: 503
+//   - other API errors: their status, except server errors, which become
+//     502 Bad Gateway since they are failures of the upstream API
+//   - context.DeadlineExceeded: 504, context.Canceled: 499
+//   - NetworkError: 504 on timeouts, otherwise 502
+//
+// Any other error yields 500.
+func HTTPStatus(err error) int {
+	var (
+		apiErr           *APIError
+		netErr           *NetworkError
+		validationErrors validator.ValidationErrors
+	)
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled):
+		return statusClientClosedRequest
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return http.StatusGatewayTimeout
+		}
+		return http.StatusBadGateway
+	case errors.Is(err, ErrAlreadyBooked):
+		return http.StatusConflict
+	case errors.Is(err, ErrAttachmentTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrMaintenance):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ErrReferenceNotFound), errors.Is(err, ErrInvalidRequest),
+		errors.As(err, &validationErrors):
+		return http.StatusBadRequest
+	case errors.As(err, &apiErr):
+		if apiErr.Status >= http.StatusInternalServerError {
+			return http.StatusBadGateway
+		}
+		if apiErr.Status >= http.StatusBadRequest {
+			return apiErr.Status
+		}
+	}
+	return http.StatusInternalServerError
+}
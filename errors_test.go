@@ -0,0 +1,37 @@
+package lardiAPI
+
+import "testing"
+
+func TestAPIErrorFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		err  APIError
+		want string
+	}{
+		{"empty", APIError{}, "API error"},
+		{"message only", APIError{Message: "invalid request"}, "API error: message=invalid request"},
+		{"status only", APIError{Status: 404}, "API error: status=404"},
+		{
+			"fields only",
+			APIError{Fields: []FieldError{{"dateFrom", "required"}, {"sizeMass", "must be positive"}}},
+			"API error: fields: dateFrom: required; sizeMass: must be positive",
+		},
+		{
+			"all",
+			APIError{
+				Status:  400,
+				Err:     "Bad Request",
+				Message: "invalid request",
+				Fields:  []FieldError{{"dateFrom", "required"}},
+			},
+			"API error: status=400, error=Bad Request, message=invalid request, fields: dateFrom: required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
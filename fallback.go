@@ -0,0 +1,81 @@
+package lardiAPI
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// withFallbackNames fills in reference names missing in the configured
+// language from Config.FallbackLanguage. A list needs the fallback when it is
+// empty or any of its entries has an empty name. An empty list is replaced by
+// the fallback list; otherwise only the empty names are filled in, matching
+// entries by ID. Errors fetching the fallback are logged and the original
+// body is kept. opts are the options of the original call.
+func (c *Client) withFallbackNames(
+	ctx context.Context, path string, body json.RawMessage, opts []RequestOption,
+) json.RawMessage {
+	fallback := c.config.FallbackLanguage
+	if fallback == "" || fallback == c.language {
+		return body
+	}
+
+	var primary []map[string]interface{}
+	if c.config.JSON.Unmarshal(body, &primary) != nil {
+		return body
+	}
+	missing := len(primary) == 0
+	for _, v := range primary {
+		if name, _ := v["name"].(string); name == "" {
+			missing = true
+		}
+	}
+	if !missing {
+		return body
+	}
+
+	// Only the identity and query of the original call apply, so that its
+	// outputs such as WithURLRef keep reporting the original request
+	o := newRequestOptions(opts)
+	fallbackOpts := []RequestOption{o.identity(), withLanguage(fallback)}
+	for key, values := range o.query {
+		if key == "language" {
+			continue
+		}
+		for _, v := range values {
+			fallbackOpts = append(fallbackOpts, WithQuery(key, v))
+		}
+	}
+
+	var fetched json.RawMessage
+	err := c.get(ctx, path, &fetched, fallbackOpts...)
+	if err == nil {
+		fetched, err = c.allPages(ctx, path, fetched, fallbackOpts)
+	}
+	if err != nil {
+		c.config.Logger.Warn("lardiAPI: failed to fetch fallback language",
+			"path", path, "language", fallback, "error", err)
+		return body
+	}
+	if len(primary) == 0 {
+		return fetched
+	}
+
+	var secondary []Response
+	if c.config.JSON.Unmarshal(fetched, &secondary) != nil {
+		return body
+	}
+	for _, v := range primary {
+		if name, _ := v["name"].(string); name != "" {
+			continue
+		}
+		id, _ := v["id"].(float64)
+		if r := findByID(secondary, int(id)); r != nil {
+			v["name"] = r.Name
+		}
+	}
+	merged, err := c.config.JSON.Marshal(primary)
+	if err != nil {
+		return body
+	}
+	return merged
+}
@@ -2,7 +2,10 @@ module github.com/fentezi/lardiAPI/v2
 
 go 1.23.2
 
-require github.com/go-playground/validator/v10 v10.22.1
+require (
+	github.com/go-playground/validator/v10 v10.22.1
+	golang.org/x/text v0.14.0
+)
 
 require (
 	github.com/gabriel-vasile/mimetype v1.4.3 // indirect
@@ -12,5 +15,4 @@ require (
 	golang.org/x/crypto v0.19.0 // indirect
 	golang.org/x/net v0.21.0 // indirect
 	golang.org/x/sys v0.17.0 // indirect
-	golang.org/x/text v0.14.0 // indirect
 )
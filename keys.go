@@ -0,0 +1,41 @@
+package lardiAPI
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// KeyProvider supplies the API key used for a request
+type KeyProvider interface {
+	Key(ctx context.Context) (string, error)
+}
+
+// StaticKey is a KeyProvider that always returns the same key
+type StaticKey string
+
+// Key returns the key
+func (k StaticKey) Key(context.Context) (string, error) {
+	return string(k), nil
+}
+
+// RoundRobinKeys is a KeyProvider that cycles through a list of keys,
+// spreading requests over several accounts
+type RoundRobinKeys struct {
+	keys []string
+	next atomic.Uint64
+}
+
+// NewRoundRobinKeys creates a KeyProvider rotating over keys
+func NewRoundRobinKeys(keys ...string) *RoundRobinKeys {
+	return &RoundRobinKeys{keys: keys}
+}
+
+// Key returns the next key in the list
+func (r *RoundRobinKeys) Key(context.Context) (string, error) {
+	if len(r.keys) == 0 {
+		return "", errors.New("no API keys configured")
+	}
+	n := r.next.Add(1) - 1
+	return r.keys[n%uint64(len(r.keys))], nil
+}
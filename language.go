@@ -0,0 +1,42 @@
+package lardiAPI
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Language is the language in which the API returns reference data
+type Language string
+
+// Supported languages
+const (
+	LanguageUK Language = "uk"
+	LanguageRU Language = "ru"
+	LanguageEN Language = "en"
+	LanguagePL Language = "pl"
+)
+
+const defaultLanguage = LanguageUK
+
+// IsValid reports whether the language is supported by the API
+func (l Language) IsValid() bool {
+	switch l {
+	case LanguageUK, LanguageRU, LanguageEN, LanguagePL:
+		return true
+	}
+	return false
+}
+
+// String returns the language code
+func (l Language) String() string {
+	return string(l)
+}
+
+// ParseLanguage converts a language code such as "uk" or "RU" to a Language
+func ParseLanguage(s string) (Language, error) {
+	l := Language(strings.ToLower(strings.TrimSpace(s)))
+	if !l.IsValid() {
+		return "", fmt.Errorf("unsupported language %q", s)
+	}
+	return l, nil
+}
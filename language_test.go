@@ -0,0 +1,80 @@
+package lardiAPI
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestNewClientLanguage(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tests := []struct {
+		lang string
+		want Language
+	}{
+		{"", LanguageUK},
+		{"ru", LanguageRU},
+		{" EN ", LanguageEN},
+		{LanguagePL.String(), LanguagePL},
+		{"de", LanguageUK},
+	}
+	for _, tt := range tests {
+		// The language typically comes from a string configuration value
+		lang := tt.lang
+		c := NewClient(Config{Language: lang, Logger: logger})
+		if c.language != tt.want || c.config.Language != tt.want.String() {
+			t.Errorf("Language %q: got %q (config %q), want %q",
+				tt.lang, c.language, c.config.Language, tt.want)
+		}
+	}
+}
+
+func TestNewClientFallbackLanguage(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tests := []struct {
+		lang Language
+		want Language
+	}{
+		{"", ""},
+		{" RU ", LanguageRU},
+		{LanguageEN, LanguageEN},
+		{"xx", ""},
+	}
+	for _, tt := range tests {
+		c := NewClient(Config{FallbackLanguage: tt.lang, Logger: logger})
+		if c.config.FallbackLanguage != tt.want {
+			t.Errorf("FallbackLanguage %q: got %q, want %q", tt.lang, c.config.FallbackLanguage, tt.want)
+		}
+	}
+}
+
+func TestFallbackKeepsCallOutputs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("language") == "ru" {
+			w.Write([]byte(`[{"id":1,"name":"Гривна"}]`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+	c := NewClient(Config{BaseURL: srv.URL, APIKey: "key", FallbackLanguage: LanguageRU})
+
+	var (
+		ref  url.URL
+		list []Response
+	)
+	err := c.getCached(context.Background(), pathCurrencies, &list, WithURLRef(&ref), WithQuery("active", "1"))
+	if err != nil {
+		t.Fatalf("getCached: %v", err)
+	}
+	if len(list) != 1 || list[0].Name != "Гривна" {
+		t.Errorf("got %+v, want the fallback list", list)
+	}
+	if q := ref.Query(); q.Get("language") != "uk" || q.Get("active") != "1" {
+		t.Errorf("URL ref = %s, want the original request", ref.String())
+	}
+}
@@ -0,0 +1,172 @@
+// Package lardiapitest provides helpers for testing code that uses the
+// Lardi-Trans API client: recording live exchanges and replaying them.
+package lardiapitest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	lardiAPI "github.com/fentezi/lardiAPI/v2"
+)
+
+const redacted = "REDACTED"
+
+// Exchange is a recorded request/response pair
+type Exchange struct {
+	Method         string      `json:"method"`
+	URL            string      `json:"url"`
+	RequestHeader  http.Header `json:"requestHeader"`
+	RequestBody    string      `json:"requestBody,omitempty"`
+	Status         int         `json:"status"`
+	ResponseHeader http.Header `json:"responseHeader"`
+	ResponseBody   string      `json:"responseBody,omitempty"`
+}
+
+// RecordingTransport wraps an HTTPClient and appends every exchange to a
+// file, one JSON object per line. The Authorization header is redacted.
+type RecordingTransport struct {
+	// RedactHeaders lists further headers to redact, e.g. a custom
+	// Config.AuthHeaderName. Set it before the first request.
+	RedactHeaders []string
+
+	next lardiAPI.HTTPClient
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewRecordingTransport creates a RecordingTransport writing to path.
+// If next is nil, http.DefaultClient is used.
+func NewRecordingTransport(next lardiAPI.HTTPClient, path string) (*RecordingTransport, error) {
+	if next == nil {
+		next = http.DefaultClient
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording file: %w", err)
+	}
+	return &RecordingTransport{next: next, file: f}, nil
+}
+
+// Do sends the request and records the exchange
+func (t *RecordingTransport) Do(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := t.next.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	header := req.Header.Clone()
+	for _, name := range append([]string{"Authorization"}, t.RedactHeaders...) {
+		if header.Get(name) != "" {
+			header.Set(name, redacted)
+		}
+	}
+	ex := Exchange{
+		Method:         req.Method,
+		URL:            req.URL.RequestURI(),
+		RequestHeader:  header,
+		RequestBody:    string(reqBody),
+		Status:         resp.StatusCode,
+		ResponseHeader: resp.Header,
+		ResponseBody:   string(respBody),
+	}
+	line, err := json.Marshal(ex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode exchange: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, err := t.file.Write(append(line, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to write exchange: %w", err)
+	}
+	return resp, nil
+}
+
+// Close closes the recording file
+func (t *RecordingTransport) Close() error {
+	return t.file.Close()
+}
+
+// ReplayTransport serves responses recorded by RecordingTransport. Requests
+// are matched by method and URL path with query, ignoring the host, so the
+// client may point at any host. Each exchange is served once, in recording
+// order.
+type ReplayTransport struct {
+	mu        sync.Mutex
+	exchanges []Exchange
+	used      []bool
+}
+
+// NewReplayTransport loads the exchanges recorded in path
+func NewReplayTransport(path string) (*ReplayTransport, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording file: %w", err)
+	}
+	defer f.Close()
+
+	var exchanges []Exchange
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 64<<20)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var ex Exchange
+		if err := json.Unmarshal(scanner.Bytes(), &ex); err != nil {
+			return nil, fmt.Errorf("failed to decode exchange: %w", err)
+		}
+		exchanges = append(exchanges, ex)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording file: %w", err)
+	}
+
+	return &ReplayTransport{exchanges: exchanges, used: make([]bool, len(exchanges))}, nil
+}
+
+// Do returns the next recorded response matching the request
+func (t *ReplayTransport) Do(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	uri := req.URL.RequestURI()
+	for i, ex := range t.exchanges {
+		if t.used[i] || ex.Method != req.Method || ex.URL != uri {
+			continue
+		}
+		t.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", ex.Status, http.StatusText(ex.Status)),
+			StatusCode:    ex.Status,
+			Header:        ex.ResponseHeader.Clone(),
+			Body:          io.NopCloser(bytes.NewReader([]byte(ex.ResponseBody))),
+			ContentLength: int64(len(ex.ResponseBody)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no recorded exchange for %s %s", req.Method, uri)
+}
@@ -0,0 +1,79 @@
+package lardiAPI
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// WithLenientDecoding makes listings such as ListMyCargos decode their
+// records one by one. Records that fail to decode are skipped, and the call
+// returns the others together with a *RecordErrors describing the skipped
+// ones, so one malformed record does not break a whole sync.
+func WithLenientDecoding() RequestOption {
+	return func(o *requestOptions) {
+		o.lenient = true
+	}
+}
+
+// RecordError is the decode error of one record of a listing page
+type RecordError struct {
+	// Index is the position of the record in the page
+	Index int
+	Err   error
+}
+
+func (e RecordError) Error() string {
+	return fmt.Sprintf("record %d: %v", e.Index, e.Err)
+}
+
+// RecordErrors is returned with the decoded records by listings called with
+// WithLenientDecoding when some records failed to decode
+type RecordErrors []RecordError
+
+func (e RecordErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d records failed to decode: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// isRecordErrors reports whether err holds RecordErrors, i.e. a listing page
+// was decoded except for some records
+func isRecordErrors(err error) bool {
+	var recordErrs RecordErrors
+	return errors.As(err, &recordErrs)
+}
+
+// decodeLenient streams the JSON array data and appends every record that
+// decodes to the slice items points to. It fails only if data is not an
+// array; the errors of single records are returned as RecordErrors.
+func (c *Client) decodeLenient(data []byte, items interface{}) error {
+	slice := reflect.ValueOf(items).Elem()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return c.decode(data, items, "page content")
+	}
+
+	var errs RecordErrors
+	for i := 0; dec.More(); i++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return fmt.Errorf("failed to read record %d of page content: %w", i, err)
+		}
+		item := reflect.New(slice.Type().Elem())
+		if err := c.decode(raw, item.Interface(), "record"); err != nil {
+			errs = append(errs, RecordError{Index: i, Err: err})
+			continue
+		}
+		slice.Set(reflect.Append(slice, item.Elem()))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
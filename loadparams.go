@@ -0,0 +1,94 @@
+package lardiAPI
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// LoadOption sets a field of LoadParams built with NewLoadParams
+type LoadOption func(*LoadParams)
+
+// WithTown sets the town name of a waypoint
+func WithTown(name string) LoadOption {
+	return func(p *LoadParams) {
+		p.TownName = name
+	}
+}
+
+// WithArea sets the area ID of a waypoint
+func WithArea(id int) LoadOption {
+	return func(p *LoadParams) {
+		p.AreaID = id
+	}
+}
+
+// WithRegion sets the region ID of a waypoint. It requires an area.
+func WithRegion(id int) LoadOption {
+	return func(p *LoadParams) {
+		p.RegionID = id
+	}
+}
+
+// WithPostCodes sets the post codes of a waypoint. They require a town.
+func WithPostCodes(codes ...string) LoadOption {
+	return func(p *LoadParams) {
+		p.PostCodes = codes
+	}
+}
+
+// WithPostCode sets the post codes of a waypoint, normalized for its country
+// with PostCode.Normalize. They require a town.
+func WithPostCode(codes ...PostCode) LoadOption {
+	return func(p *LoadParams) {
+		p.PostCodes = make([]string, len(codes))
+		for i, code := range codes {
+			p.PostCodes[i] = string(code.Normalize(p.CountrySign))
+		}
+	}
+}
+
+// NewLoadParams builds a waypoint in the country with the given two-letter
+// sign, e.g. "UA", and checks it with Validate
+func NewLoadParams(countrySign string, opts ...LoadOption) (LoadParams, error) {
+	p := LoadParams{CountrySign: strings.ToUpper(strings.TrimSpace(countrySign))}
+	for _, opt := range opts {
+		opt(&p)
+	}
+	if err := p.Validate(); err != nil {
+		return LoadParams{}, err
+	}
+	return p, nil
+}
+
+// Validate checks that the fields of the waypoint fit together: a country
+// sign and a town or area are required, a region requires an area and post
+// codes require a town. Post codes are checked with PostCode.Validate.
+// The error matches ErrInvalidRequest.
+func (p *LoadParams) Validate() error {
+	var errs []error
+	if !isCountrySign(p.CountrySign) {
+		errs = append(errs, fmt.Errorf("invalid country sign %q", p.CountrySign))
+	}
+	if p.TownName == "" && p.AreaID == 0 {
+		errs = append(errs, errors.New("town or area is required"))
+	}
+	if p.AreaID < 0 || p.RegionID < 0 {
+		errs = append(errs, errors.New("area and region IDs must not be negative"))
+	}
+	if p.RegionID != 0 && p.AreaID == 0 {
+		errs = append(errs, errors.New("region requires an area"))
+	}
+	if len(p.PostCodes) > 0 && p.TownName == "" {
+		errs = append(errs, errors.New("post codes require a town"))
+	}
+	for _, code := range p.PostCodes {
+		if err := PostCode(code).Validate(p.CountrySign); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	return nil
+}
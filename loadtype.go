@@ -0,0 +1,99 @@
+package lardiAPI
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// LoadType is a loading method. Its values are not IDs of the API, which
+// does not document its load type IDs as fixed: LoadTypeIDs resolves load
+// types to the IDs of the load types reference by their names.
+type LoadType int
+
+// Load types
+const (
+	LoadTypeTop LoadType = iota + 1
+	LoadTypeSide
+	LoadTypeRear
+)
+
+var loadTypeNames = map[LoadType][]string{
+	LoadTypeTop:  {"top", "верхня", "верхняя"},
+	LoadTypeSide: {"side", "бокова", "боковая"},
+	LoadTypeRear: {"rear", "задня", "задняя"},
+}
+
+// String returns the English name of the load type
+func (t LoadType) String() string {
+	if names, ok := loadTypeNames[t]; ok {
+		return names[0]
+	}
+	return fmt.Sprintf("LoadType(%d)", int(t))
+}
+
+// ParseLoadType converts a load type name in English, Ukrainian or Russian,
+// e.g. "side" or "бокова", to a LoadType
+func ParseLoadType(name string) (LoadType, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for t, names := range loadTypeNames {
+		for _, n := range names {
+			if n == name {
+				return t, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unknown load type %q", name)
+}
+
+// LoadTypeIDs returns the IDs of types in the load types reference, in
+// order, for CargoRequest.LoadTypes. Types missing from the reference are
+// reported together as errors wrapping ErrReferenceNotFound.
+func (c *Client) LoadTypeIDs(ctx context.Context, types []LoadType, opts ...RequestOption) ([]int, error) {
+	list, err := c.GetLoadTypes(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return loadTypeIDs(list, types)
+}
+
+// LoadTypeIDs is like Client.LoadTypeIDs, but resolves the types against the
+// loaded list without network calls
+func (r *References) LoadTypeIDs(types ...LoadType) ([]int, error) {
+	return loadTypeIDs(r.LoadTypes, types)
+}
+
+// SetLoadTypes sets the LoadTypes of req to the IDs of types, resolved with
+// LoadTypeIDs. On error req is left unchanged.
+func (c *Client) SetLoadTypes(
+	ctx context.Context, req *CargoRequest, types []LoadType, opts ...RequestOption,
+) error {
+	ids, err := c.LoadTypeIDs(ctx, types, opts...)
+	if err != nil {
+		return err
+	}
+	req.LoadTypes = ids
+	return nil
+}
+
+func loadTypeIDs(list []Response, types []LoadType) ([]int, error) {
+	ids := make([]int, len(types))
+	var errs []error
+	for i, t := range types {
+		j := slices.IndexFunc(list, func(v Response) bool {
+			named, err := ParseLoadType(v.Name)
+			return err == nil && named == t
+		})
+		if j < 0 {
+			errs = append(errs, fmt.Errorf("load type %s: %w", t, ErrReferenceNotFound))
+			continue
+		}
+		ids[i] = list[j].ID
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
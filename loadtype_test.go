@@ -0,0 +1,46 @@
+package lardiAPI
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestLoadTypeIDs(t *testing.T) {
+	// IDs unrelated to the order of the constants
+	refs := &References{LoadTypes: []Response{
+		{ID: 7, Name: "Задняя"}, {ID: 12, Name: "Верхняя"}, {ID: 15, Name: "Боковая"},
+	}}
+	ids, err := refs.LoadTypeIDs(LoadTypeTop, LoadTypeSide, LoadTypeRear)
+	if err != nil || !slices.Equal(ids, []int{12, 15, 7}) {
+		t.Errorf("LoadTypeIDs = %v, %v, want [12 15 7]", ids, err)
+	}
+
+	refs.LoadTypes = refs.LoadTypes[1:]
+	if ids, err := refs.LoadTypeIDs(LoadTypeTop, LoadTypeRear); !errors.Is(err, ErrReferenceNotFound) {
+		t.Errorf("LoadTypeIDs without rear = %v, %v, want ErrReferenceNotFound", ids, err)
+	}
+}
+
+func TestClientSetLoadTypes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":21,"name":"Верхня"},{"id":22,"name":"Бокова"}]`))
+	}))
+	defer srv.Close()
+	c := NewClient(Config{BaseURL: srv.URL, APIKey: "key"})
+
+	req := testCargoRequest()
+	if err := c.SetLoadTypes(context.Background(), req, []LoadType{LoadTypeSide}); err != nil {
+		t.Fatalf("SetLoadTypes: %v", err)
+	}
+	if !slices.Equal(req.LoadTypes, []int{22}) {
+		t.Errorf("LoadTypes = %v, want [22]", req.LoadTypes)
+	}
+	err := c.SetLoadTypes(context.Background(), req, []LoadType{LoadTypeRear})
+	if !errors.Is(err, ErrReferenceNotFound) || !slices.Equal(req.LoadTypes, []int{22}) {
+		t.Errorf("SetLoadTypes(rear) = %v, LoadTypes %v; want ErrReferenceNotFound, unchanged", err, req.LoadTypes)
+	}
+}
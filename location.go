@@ -0,0 +1,213 @@
+package lardiAPI
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Area represents an entry of the areas reference
+type Area struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	CountrySign string `json:"countrySign"`
+	RegionID    int    `json:"regionId"`
+	ParentID    int    `json:"parentId,omitempty"`
+	Children    []Area `json:"children,omitempty"`
+}
+
+// AmbiguousLocationError is returned when a location matches several areas
+type AmbiguousLocationError struct {
+	Query      string
+	Candidates []LoadParams
+}
+
+func (e *AmbiguousLocationError) Error() string {
+	return fmt.Sprintf("location %q is ambiguous: %d candidates", e.Query, len(e.Candidates))
+}
+
+// countrySigns maps common country names to their signs
+var countrySigns = map[string]string{
+	"україна":   "UA",
+	"украина":   "UA",
+	"ukraine":   "UA",
+	"польща":    "PL",
+	"польша":    "PL",
+	"poland":    "PL",
+	"молдова":   "MD",
+	"moldova":   "MD",
+	"румунія":   "RO",
+	"румыния":   "RO",
+	"romania":   "RO",
+	"німеччина": "DE",
+	"германия":  "DE",
+	"germany":   "DE",
+}
+
+// ResolveLocation builds LoadParams from free text such as "Kyiv, Ukraine".
+// The first comma-separated part is the town, the remaining parts are a
+// country (name or two-letter sign) or an area name. Without an area name the
+// town itself is looked up among the areas. If several areas
+// match, an *AmbiguousLocationError listing the candidates is returned.
+func (c *Client) ResolveLocation(
+	ctx context.Context, text string, opts ...RequestOption,
+) (*LoadParams, error) {
+	areas, err := c.getAreaList(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("resolve location failed: %w", err)
+	}
+	return resolveLocation(areas, text, c.config.TransliterateNames)
+}
+
+// ResolveLocations resolves many locations against a single fetch of the
+// areas reference. Names that are not found or are ambiguous are returned
+// in unresolved; use ResolveLocation to get the candidates of one of them.
+func (c *Client) ResolveLocations(
+	ctx context.Context, names []string, opts ...RequestOption,
+) (resolved map[string]*LoadParams, unresolved []string, err error) {
+	areas, err := c.getAreaList(ctx, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolve locations failed: %w", err)
+	}
+
+	resolved = make(map[string]*LoadParams, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		p, err := resolveLocation(areas, name, c.config.TransliterateNames)
+		if err != nil {
+			unresolved = append(unresolved, name)
+			continue
+		}
+		resolved[name] = p
+	}
+	return resolved, unresolved, nil
+}
+
+func resolveLocation(areas []Area, text string, transliterated bool) (*LoadParams, error) {
+	var parts []string
+	for _, p := range strings.Split(text, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("location %q: empty query", text)
+	}
+	town, qualifiers := parts[0], parts[1:]
+
+	var countrySign string
+	var areaNames []string
+	for _, q := range qualifiers {
+		if sign, ok := parseCountrySign(q); ok {
+			countrySign = sign
+			continue
+		}
+		areaNames = append(areaNames, q)
+	}
+
+	if len(areaNames) == 0 {
+		areaNames = []string{town}
+	}
+
+	var candidates []LoadParams
+	for _, a := range areas {
+		if !containsName(areaNames, a.Name, transliterated) {
+			continue
+		}
+		if countrySign != "" && a.CountrySign != "" && a.CountrySign != countrySign {
+			continue
+		}
+		sign := a.CountrySign
+		if sign == "" {
+			sign = countrySign
+		}
+		candidates = append(candidates, LoadParams{
+			TownName:    town,
+			AreaID:      a.ID,
+			CountrySign: sign,
+			RegionID:    a.RegionID,
+		})
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("location %q: %w", text, ErrReferenceNotFound)
+	case 1:
+		return &candidates[0], nil
+	}
+	return nil, &AmbiguousLocationError{Query: text, Candidates: candidates}
+}
+
+// ResolveWaypoint checks that the town, area and country of p identify
+// exactly one area and returns p with the area, country sign and region
+// filled in from the reference. If p has no AreaID, the town name is looked
+// up among the areas. An *AmbiguousLocationError lists the candidates when
+// several areas match.
+func (c *Client) ResolveWaypoint(
+	ctx context.Context, p LoadParams, opts ...RequestOption,
+) (LoadParams, error) {
+	areas, err := c.getAreaList(ctx, opts...)
+	if err != nil {
+		return p, fmt.Errorf("resolve waypoint failed: %w", err)
+	}
+	return resolveWaypoint(areas, p, c.config.TransliterateNames)
+}
+
+func resolveWaypoint(areas []Area, p LoadParams, transliterated bool) (LoadParams, error) {
+	var candidates []LoadParams
+	for _, a := range areas {
+		if p.AreaID != 0 && a.ID != p.AreaID {
+			continue
+		}
+		if p.AreaID == 0 && !namesMatch(a.Name, p.TownName, transliterated) {
+			continue
+		}
+		if p.CountrySign != "" && a.CountrySign != "" && !strings.EqualFold(a.CountrySign, p.CountrySign) {
+			continue
+		}
+		if p.RegionID != 0 && a.RegionID != 0 && a.RegionID != p.RegionID {
+			continue
+		}
+		canonical := p
+		canonical.AreaID = a.ID
+		if a.CountrySign != "" {
+			canonical.CountrySign = a.CountrySign
+		}
+		if a.RegionID != 0 {
+			canonical.RegionID = a.RegionID
+		}
+		candidates = append(candidates, canonical)
+	}
+
+	query := fmt.Sprintf("%s (area %d, %s)", p.TownName, p.AreaID, p.CountrySign)
+	switch len(candidates) {
+	case 0:
+		return p, fmt.Errorf("waypoint %s: %w", query, ErrReferenceNotFound)
+	case 1:
+		return candidates[0], nil
+	}
+	return p, &AmbiguousLocationError{Query: query, Candidates: candidates}
+}
+
+func parseCountrySign(s string) (string, bool) {
+	if sign, ok := countrySigns[strings.ToLower(s)]; ok {
+		return sign, true
+	}
+	if len(s) == 2 && strings.ToUpper(s) == s {
+		return s, true
+	}
+	return "", false
+}
+
+func containsName(list []string, s string, transliterated bool) bool {
+	for _, v := range list {
+		if namesMatch(v, s, transliterated) {
+			return true
+		}
+	}
+	return false
+}
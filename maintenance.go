@@ -0,0 +1,69 @@
+package lardiAPI
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrMaintenance matches errors returned while the API is down for
+// maintenance. errors.As with *MaintenanceError gives the expected end.
+var ErrMaintenance = errors.New("API is under maintenance")
+
+// MaintenanceError is returned when the API responds with 503 Service
+// Unavailable because of maintenance. Such responses are not retried, since
+// retrying before the maintenance ends is pointless.
+type MaintenanceError struct {
+	APIError
+	// Until is the expected end of the maintenance, zero if the API did not
+	// report it
+	Until time.Time
+}
+
+func (e *MaintenanceError) Error() string {
+	if e.Until.IsZero() {
+		return ErrMaintenance.Error()
+	}
+	return ErrMaintenance.Error() + " until " + e.Until.Format(time.RFC3339)
+}
+
+func (e *MaintenanceError) Unwrap() error {
+	return &e.APIError
+}
+
+func (e *MaintenanceError) Is(target error) bool {
+	return target == ErrMaintenance
+}
+
+// isMaintenance reports whether a 503 response is a maintenance response:
+// it has an X-Maintenance header or mentions maintenance in the body, which
+// may be an HTML page rather than a JSON error
+func isMaintenance(header http.Header, body []byte) bool {
+	return header.Get("X-Maintenance") != "" || header.Get("X-Maintenance-End") != "" ||
+		bytes.Contains(bytes.ToLower(body), []byte("maintenance"))
+}
+
+// maintenanceEnd parses the end of the maintenance from X-Maintenance-End,
+// an RFC 3339 or HTTP date, or from Retry-After, an HTTP date or a number of
+// seconds. It returns the zero time if neither is usable.
+func maintenanceEnd(header http.Header, now time.Time) time.Time {
+	if v := header.Get("X-Maintenance-End"); v != "" {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return t
+		}
+	}
+	if v := header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+			return now.Add(time.Duration(seconds) * time.Second)
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
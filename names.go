@@ -0,0 +1,52 @@
+package lardiAPI
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeName returns the form of a town or reference name used for
+// matching: Unicode NFC, case-folded, with apostrophe variants unified and
+// runs of whitespace collapsed, so that "  КАМ’ЯНСЬКЕ" and "Кам'янське"
+// compare equal.
+func NormalizeName(name string) string {
+	name = cases.Fold().String(norm.NFC.String(name))
+	name = apostrophes.Replace(name)
+	return strings.Join(strings.Fields(name), " ")
+}
+
+var apostrophes = strings.NewReplacer("’", "'", "ʼ", "'", "`", "'", "‘", "'")
+
+// namesMatch reports whether two names are equal after normalization. With
+// transliterated set, names that still differ are compared transliterated
+// to Latin, which matches names typed with a mix of Cyrillic and Latin
+// letters, e.g. "Kиїв" with a Latin K.
+func namesMatch(a, b string, transliterated bool) bool {
+	a, b = NormalizeName(a), NormalizeName(b)
+	return a == b || transliterated && transliterate(a) == transliterate(b)
+}
+
+// transliterate converts the Cyrillic letters of a normalized name to Latin
+// following the Ukrainian national system, with Russian-only letters added
+func transliterate(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if t, ok := cyrillicToLatin[r]; ok {
+			b.WriteString(t)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+var cyrillicToLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "h", 'ґ': "g", 'д': "d", 'е': "e",
+	'є': "ie", 'ж': "zh", 'з': "z", 'и': "y", 'і': "i", 'ї': "i", 'й': "i",
+	'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch",
+	'ш': "sh", 'щ': "shch", 'ь': "", 'ю': "iu", 'я': "ia", '\'': "",
+	'ё': "e", 'ы': "y", 'э': "e", 'ъ': "",
+}
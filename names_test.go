@@ -0,0 +1,63 @@
+package lardiAPI
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"Київ", "КИЇВ"},
+		{"  Кам’янське ", "кам'янське"},
+		{"Біла  Церква", "біла церква"},
+		{"Ростов-на-Дону", "РОСТОВ-НА-ДОНУ"},
+		{"Санкт-Петербург", "санкт-петербург"},
+		// "й" decomposed into "и" and a combining breve
+		{"Кривий Ріг", "Кривии\u0306 Ріг"},
+	}
+	for _, tt := range tests {
+		if NormalizeName(tt.a) != NormalizeName(tt.b) {
+			t.Errorf("NormalizeName(%q) = %q, NormalizeName(%q) = %q, want equal",
+				tt.a, NormalizeName(tt.a), tt.b, NormalizeName(tt.b))
+		}
+	}
+}
+
+func TestNamesMatch(t *testing.T) {
+	tests := []struct {
+		a, b         string
+		plain, latin bool
+	}{
+		{"Київ", "київ", true, true},
+		{"Одеса", "ОДЕСА", true, true},
+		{"Москва", "москва", true, true},
+		// Latin K typed in a Cyrillic name
+		{"Київ", "Kиїв", false, true},
+		{"Харків", "Kharkiv", false, true},
+		{"Нижний Новгород", "Nyzhnyi Novhorod", false, true},
+		{"Львів", "Луцьк", false, false},
+		{"Київ", "Kyiv", false, true},
+	}
+	for _, tt := range tests {
+		if got := namesMatch(tt.a, tt.b, false); got != tt.plain {
+			t.Errorf("namesMatch(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.plain)
+		}
+		if got := namesMatch(tt.a, tt.b, true); got != tt.latin {
+			t.Errorf("namesMatch(%q, %q) transliterated = %v, want %v", tt.a, tt.b, got, tt.latin)
+		}
+	}
+}
+
+func TestReferencesTransliterate(t *testing.T) {
+	refs := &References{Units: []Response{{ID: 3, Name: "грн/км"}}}
+	// "км" typed with a Latin k
+	if _, err := refs.Unit("грн/kм"); !errors.Is(err, ErrReferenceNotFound) {
+		t.Errorf("Unit without transliteration: err = %v, want ErrReferenceNotFound", err)
+	}
+	refs.Transliterate = true
+	if id, err := refs.Unit("грн/kм"); err != nil || id != 3 {
+		t.Errorf("Unit with transliteration = %d, %v, want 3", id, err)
+	}
+}
@@ -0,0 +1,88 @@
+package lardiAPI
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// offlineHTTP answers requests of a client with Config.Offline set with
+// deterministic stub data instead of sending them
+type offlineHTTP struct {
+	// basePath is the path prefix of Config.BaseURL
+	basePath string
+	nextID   atomic.Int64
+}
+
+// offlineCargoPath matches the path of a single cargo proposal
+var offlineCargoPath = regexp.MustCompile(`^/proposals/my/cargo/([0-9]+)$`)
+
+func (t *offlineHTTP) Do(req *http.Request) (*http.Response, error) {
+	// Strip the base path and the API version
+	path := strings.TrimPrefix(strings.TrimPrefix(req.URL.Path, t.basePath), "/")
+	_, path, _ = strings.Cut(path, "/")
+	path = "/" + path
+
+	var body []byte
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		body = data
+	}
+
+	status, resp := t.stub(req.Method, path, body)
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(resp)),
+		Request:    req,
+	}, nil
+}
+
+// stub returns the status and body of the stub response to a request
+func (t *offlineHTTP) stub(method, path string, body []byte) (int, []byte) {
+	switch {
+	case path == pathRefVersion:
+		// Without a reference version only CacheTTL applies
+		return http.StatusNotFound, []byte(`{"status":404,"message":"offline"}`)
+	case strings.HasPrefix(path, "/references/"):
+		return http.StatusOK, []byte(`[{"id":1,"name":"stub"}]`)
+	case path == pathContacts || path == pathBranches || path == pathWebhooks && method == http.MethodGet:
+		return http.StatusOK, []byte(`[]`)
+	case path == pathMyCargos || path == pathDeals || path == pathSearchCargo:
+		return http.StatusOK, []byte(`{"content":[],"page":1,"totalPages":1}`)
+	case path == pathDelete:
+		var req DeleteCargo
+		_ = json.Unmarshal(body, &req)
+		data, _ := json.Marshal(DeleteResponse{Success: req.CargoIds})
+		return http.StatusOK, data
+	case path == pathCargoBulk:
+		var reqs []json.RawMessage
+		_ = json.Unmarshal(body, &reqs)
+		resps := make([]CargoResponse, len(reqs))
+		for i := range resps {
+			resps[i].ID = t.id()
+		}
+		data, _ := json.Marshal(resps)
+		return http.StatusOK, data
+	case method == http.MethodGet:
+		if m := offlineCargoPath.FindStringSubmatch(path); m != nil {
+			return http.StatusOK, []byte(`{"id":` + m[1] + `}`)
+		}
+		return http.StatusOK, []byte(`{}`)
+	}
+	return http.StatusOK, []byte(fmt.Sprintf(`{"id":%d}`, t.id()))
+}
+
+// id returns the next stub ID, counting from 1
+func (t *offlineHTTP) id() int {
+	return int(t.nextID.Add(1))
+}
@@ -0,0 +1,167 @@
+package lardiAPI
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RequestOption customizes a single API call
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	header      http.Header
+	language    Language
+	noLanguage  bool
+	apiVersion  string
+	timing      *Timing
+	deadline    time.Time
+	urlRef      *url.URL
+	contentType string
+	priority    Priority
+	onResponse  func(*http.Response)
+	checkpoint  BatchCheckpoint
+	progress    func(BatchResult)
+	query       url.Values
+	retries     *int
+	lenient     bool
+	apiKey      string
+}
+
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	o := &requestOptions{header: make(http.Header), query: make(url.Values)}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithAPIVersion sends the call to the given API version, e.g. "v3",
+// instead of Config.APIVersion
+func WithAPIVersion(version string) RequestOption {
+	return func(o *requestOptions) {
+		o.apiVersion = version
+	}
+}
+
+// WithoutLanguage omits the language query parameter, for endpoints that
+// reject unknown parameters
+func WithoutLanguage() RequestOption {
+	return func(o *requestOptions) {
+		o.noLanguage = true
+	}
+}
+
+// Timing reports the client-side latency of a call
+type Timing struct {
+	// Total is the duration of the whole call, including retries and the
+	// waits between them
+	Total time.Duration
+	// Attempts holds the round-trip time of every attempt in order
+	Attempts []time.Duration
+}
+
+// RetryTime returns the time spent after the first attempt, retrying and
+// waiting between retries
+func (t *Timing) RetryTime() time.Duration {
+	if len(t.Attempts) == 0 {
+		return 0
+	}
+	return t.Total - t.Attempts[0]
+}
+
+// WithTiming fills t with the latency of the call. For calls that send
+// several requests, such as paginated reference lists, t describes the last
+// request.
+func WithTiming(t *Timing) RequestOption {
+	return func(o *requestOptions) {
+		o.timing = t
+	}
+}
+
+// WithDeadline makes the call fail with context.DeadlineExceeded if it has
+// not completed by t. It is a shorthand for context.WithDeadline.
+func WithDeadline(t time.Time) RequestOption {
+	return func(o *requestOptions) {
+		o.deadline = t
+	}
+}
+
+// WithURLRef stores the final URL of the request in u, including the query
+// parameters added by the client, which helps to debug query issues
+func WithURLRef(u *url.URL) RequestOption {
+	return func(o *requestOptions) {
+		o.urlRef = u
+	}
+}
+
+// WithContentType sets the Content-Type of the request body, which defaults
+// to application/json. Bodies passed as io.Reader or []byte are sent as is.
+func WithContentType(contentType string) RequestOption {
+	return func(o *requestOptions) {
+		o.contentType = contentType
+	}
+}
+
+// WithQuery adds a query parameter to the request, e.g. a filter the typed
+// methods do not expose yet. It can be given several times, also for the
+// same key. A "language" parameter replaces the one added by the client.
+func WithQuery(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.query.Add(key, value)
+	}
+}
+
+// WithRetries sets the number of retries of the call, taking precedence
+// over Config.RetryOverrides and Config.MaxRetries. WithRetries(0) disables
+// retries. POST requests are still only retried with Config.DedupeOnRetry.
+func WithRetries(n int) RequestOption {
+	return func(o *requestOptions) {
+		o.retries = &n
+	}
+}
+
+// WithAPIKey sends the call with key instead of the key from
+// Config.KeyProvider, e.g. for a service working for several accounts with
+// one client. Config.RetryUnauthorized does not switch such calls to another
+// key. Cached reference data is kept apart per key.
+func WithAPIKey(key string) RequestOption {
+	return func(o *requestOptions) {
+		o.apiKey = key
+	}
+}
+
+// withHeader sets a request header
+func withHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.header.Set(key, value)
+	}
+}
+
+// withResponse registers fn to inspect the response of every attempt
+func withResponse(fn func(*http.Response)) RequestOption {
+	return func(o *requestOptions) {
+		o.onResponse = fn
+	}
+}
+
+// withLanguage overrides the language of the request
+func withLanguage(lang Language) RequestOption {
+	return func(o *requestOptions) {
+		o.language = lang
+	}
+}
+
+// identity returns an option carrying the API key, API version, language and
+// headers of o, for follow-up requests made on behalf of a call. Outputs
+// such as WithURLRef and WithTiming and the query of o are left out, so the
+// follow-up neither reports into them nor inherits the call's parameters.
+func (o *requestOptions) identity() RequestOption {
+	return func(to *requestOptions) {
+		to.apiKey = o.apiKey
+		to.apiVersion = o.apiVersion
+		to.language = o.language
+		to.noLanguage = o.noLanguage
+		to.header = o.header.Clone()
+	}
+}
@@ -0,0 +1,46 @@
+package lardiAPI
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PackageSize is the mass and volume of one package, in the units of
+// SizeMass and SizeVolume. Volume is optional; zero means unknown.
+type PackageSize struct {
+	Mass   float64
+	Volume float64
+}
+
+// ComputeTotals sets SizeMass to the sum of the package counts of
+// CargoPackaging multiplied by the mass of one package, keyed by package
+// type ID, e.g. {1: {Mass: 0.8}, 5: {Mass: 0.02}} for pallets and boxes in
+// tonnes. When some packages also have a volume, SizeVolume is set to the
+// sum of the known volumes; otherwise it is left as is. Every package type
+// of CargoPackaging needs a size and the total mass must be positive. On
+// error the request is left unchanged.
+func (r *CargoRequest) ComputeTotals(packages map[int]PackageSize) error {
+	if len(r.CargoPackaging) == 0 {
+		return errors.New("cargo packaging is empty")
+	}
+	var mass, volume float64
+	for _, p := range r.CargoPackaging {
+		size, ok := packages[p.ID]
+		if !ok {
+			return fmt.Errorf("no size for package type %d", p.ID)
+		}
+		if p.Count < 0 || size.Mass < 0 || size.Volume < 0 {
+			return fmt.Errorf("package type %d: count, mass and volume must not be negative", p.ID)
+		}
+		mass += float64(p.Count) * size.Mass
+		volume += float64(p.Count) * size.Volume
+	}
+	if mass <= 0 {
+		return fmt.Errorf("total mass must be positive, got %g", mass)
+	}
+	r.SizeMass = mass
+	if volume > 0 {
+		r.SizeVolume = volume
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package lardiAPI
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeTotals(t *testing.T) {
+	sizes := map[int]PackageSize{
+		1: {Mass: 0.8, Volume: 1.5},   // pallet
+		5: {Mass: 0.02, Volume: 0.05}, // box
+		9: {Mass: 0.5, Volume: 0},     // bundle of rods, negligible volume
+		8: {Mass: 0, Volume: 0.3},     // empty crate
+	}
+	tests := []struct {
+		name         string
+		packs        []CargoPack
+		mass, volume float64
+		wantErr      bool
+	}{
+		{"pallets only", []CargoPack{{ID: 1, Count: 10}}, 8, 15, false},
+		{"pallets and boxes", []CargoPack{{ID: 1, Count: 2}, {ID: 5, Count: 30}}, 2.2, 4.5, false},
+		{"same type twice", []CargoPack{{ID: 5, Count: 10}, {ID: 5, Count: 10}}, 0.4, 1, false},
+		{"mixed with zero volume type", []CargoPack{{ID: 9, Count: 4}, {ID: 5, Count: 10}}, 2.2, 0.5, false},
+		{"zero volume only keeps volume", []CargoPack{{ID: 9, Count: 4}}, 2, 2, false},
+		{"zero mass", []CargoPack{{ID: 8, Count: 4}}, 0, 0, true},
+		{"unknown type", []CargoPack{{ID: 1, Count: 1}, {ID: 7, Count: 1}}, 0, 0, true},
+		{"zero counts", []CargoPack{{ID: 1}, {ID: 5}}, 0, 0, true},
+		{"negative count", []CargoPack{{ID: 1, Count: 3}, {ID: 5, Count: -1}}, 0, 0, true},
+		{"empty", nil, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &CargoRequest{CargoPackaging: tt.packs, SizeMass: 1, SizeVolume: 2}
+			err := r.ComputeTotals(sizes)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ComputeTotals = nil, want error")
+				}
+				if r.SizeMass != 1 || r.SizeVolume != 2 {
+					t.Errorf("request changed on error: mass %g, volume %g", r.SizeMass, r.SizeVolume)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ComputeTotals: %v", err)
+			}
+			if math.Abs(r.SizeMass-tt.mass) > 1e-9 || math.Abs(r.SizeVolume-tt.volume) > 1e-9 {
+				t.Errorf("got mass %g, volume %g, want %g, %g", r.SizeMass, r.SizeVolume, tt.mass, tt.volume)
+			}
+		})
+	}
+}
+
+func TestComputeTotalsWeightsOnly(t *testing.T) {
+	weights := map[int]PackageSize{1: {Mass: 0.8}, 5: {Mass: 0.02}}
+	r := &CargoRequest{CargoPackaging: []CargoPack{{ID: 1, Count: 3}, {ID: 5, Count: 40}}}
+	if err := r.ComputeTotals(weights); err != nil {
+		t.Fatalf("ComputeTotals: %v", err)
+	}
+	if math.Abs(r.SizeMass-3.2) > 1e-9 || r.SizeVolume != 0 {
+		t.Errorf("got mass %g, volume %g, want 3.2 and no volume", r.SizeMass, r.SizeVolume)
+	}
+}
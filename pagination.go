@@ -0,0 +1,208 @@
+package lardiAPI
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+// PageInfo describes a page of a listing endpoint.
+//
+// Endpoints paginate either by page number or by an opaque cursor. In a
+// request, a non-empty Cursor takes precedence over Page. In a response,
+// NextCursor is set only by cursor-based endpoints; otherwise Page and
+// TotalPages describe the position in the listing. Pages are 1-based.
+type PageInfo struct {
+	Page       int
+	Size       int
+	Cursor     string
+	NextCursor string
+	TotalPages int
+	TotalItems int
+}
+
+// UsesCursor reports whether the endpoint paginates by cursor
+func (p *PageInfo) UsesCursor() bool {
+	return p.NextCursor != "" || p.Cursor != ""
+}
+
+// HasNext reports whether there are more pages after this one
+func (p *PageInfo) HasNext() bool {
+	if p.UsesCursor() {
+		return p.NextCursor != ""
+	}
+	return p.Page < p.TotalPages
+}
+
+// Next returns the request for the page following this one
+func (p *PageInfo) Next() PageInfo {
+	if p.UsesCursor() {
+		return PageInfo{Size: p.Size, Cursor: p.NextCursor}
+	}
+	return PageInfo{Size: p.Size, Page: p.Page + 1}
+}
+
+func (p PageInfo) query() url.Values {
+	q := url.Values{}
+	if p.Cursor != "" {
+		q.Set("cursor", p.Cursor)
+	} else if p.Page > 0 {
+		q.Set("page", strconv.Itoa(p.Page))
+	}
+	if p.Size > 0 {
+		q.Set("size", strconv.Itoa(p.Size))
+	}
+	return q
+}
+
+// pageResponse is the envelope returned by listing endpoints
+type pageResponse struct {
+	Content       json.RawMessage `json:"content"`
+	Page          int             `json:"page"`
+	Size          int             `json:"size"`
+	TotalPages    int             `json:"totalPages"`
+	TotalElements int             `json:"totalElements"`
+	NextCursor    string          `json:"nextCursor"`
+}
+
+// getPage fetches one page of a listing endpoint and decodes its items. With
+// WithLenientDecoding, the records that decode are kept and the page info is
+// returned together with the RecordErrors of the others.
+func (c *Client) getPage(
+	ctx context.Context, path string, query url.Values, page PageInfo, items interface{},
+	opts ...RequestOption,
+) (*PageInfo, error) {
+	q := page.query()
+	for k, v := range query {
+		q[k] = v
+	}
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	var resp pageResponse
+	if err := c.get(ctx, path, &resp, opts...); err != nil {
+		return nil, err
+	}
+	info := &PageInfo{
+		Page:       resp.Page,
+		Size:       resp.Size,
+		Cursor:     page.Cursor,
+		NextCursor: resp.NextCursor,
+		TotalPages: resp.TotalPages,
+		TotalItems: resp.TotalElements,
+	}
+	if len(resp.Content) == 0 {
+		return info, nil
+	}
+	if newRequestOptions(opts).lenient {
+		if err := c.decodeLenient(resp.Content, items); err != nil {
+			if isRecordErrors(err) {
+				return info, err
+			}
+			return nil, err
+		}
+		return info, nil
+	}
+	if err := c.decode(resp.Content, items, "page content"); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// allPages assembles the items of a paginated reference response into a
+// single JSON array by fetching the remaining pages. Bodies that are not a
+// page envelope are returned as is.
+func (c *Client) allPages(
+	ctx context.Context, path string, body json.RawMessage, opts []RequestOption,
+) (json.RawMessage, error) {
+	var first pageResponse
+	if c.config.JSON.Unmarshal(body, &first) != nil || first.Content == nil {
+		return body, nil
+	}
+
+	var items []json.RawMessage
+	if err := c.decode(first.Content, &items, "page content"); err != nil {
+		return nil, err
+	}
+	page := &PageInfo{
+		Page:       first.Page,
+		Size:       first.Size,
+		NextCursor: first.NextCursor,
+		TotalPages: first.TotalPages,
+	}
+	for page.HasNext() {
+		var next []json.RawMessage
+		var err error
+		page, err = c.getPage(ctx, path, nil, page.Next(), &next, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if len(next) == 0 {
+			break
+		}
+		items = append(items, next...)
+	}
+
+	return c.config.JSON.Marshal(items)
+}
+
+// Iterator walks through all items of a listing, fetching pages on demand
+// and following page numbers or cursors transparently.
+//
+//	it := client.IterateMyCargos(ctx, lardiAPI.PageInfo{Size: 50})
+//	for it.Next() {
+//		cargo := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch func(context.Context, PageInfo) ([]T, *PageInfo, error)
+	next  PageInfo
+	items []T
+	item  T
+	done  bool
+	err   error
+}
+
+func newIterator[T any](
+	ctx context.Context, page PageInfo, fetch func(context.Context, PageInfo) ([]T, *PageInfo, error),
+) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, fetch: fetch, next: page}
+}
+
+// Next advances to the next item, fetching the next page when needed.
+// It returns false when the listing is exhausted or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		items, page, err := it.fetch(it.ctx, it.next)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items = items
+		if page.HasNext() && len(items) > 0 {
+			it.next = page.Next()
+		} else {
+			it.done = true
+		}
+	}
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Item returns the current item
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
@@ -0,0 +1,62 @@
+package lardiAPI
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetCachedAllPages(t *testing.T) {
+	// Three pages of two items each, addressed by page number or cursor
+	pages := []string{
+		`[{"id":1,"name":"UAH"},{"id":2,"name":"USD"}]`,
+		`[{"id":3,"name":"EUR"},{"id":4,"name":"PLN"}]`,
+		`[{"id":5,"name":"GBP"}]`,
+	}
+	tests := []struct {
+		name string
+		page func(r *http.Request) (int, string)
+	}{
+		{"page numbers", func(r *http.Request) (int, string) {
+			n := 1
+			fmt.Sscan(r.URL.Query().Get("page"), &n)
+			return n, fmt.Sprintf(`"page":%d,"size":2,"totalPages":3`, n)
+		}},
+		{"cursor", func(r *http.Request) (int, string) {
+			n := 1
+			fmt.Sscanf(r.URL.Query().Get("cursor"), "c%d", &n)
+			next := ""
+			if n < len(pages) {
+				next = fmt.Sprintf("c%d", n+1)
+			}
+			return n, fmt.Sprintf(`"size":2,"nextCursor":%q`, next)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				n, info := tt.page(r)
+				fmt.Fprintf(w, `{"content":%s,%s}`, pages[n-1], info)
+			}))
+			defer srv.Close()
+			c := NewClient(Config{BaseURL: srv.URL, APIKey: "key"})
+
+			for range 2 {
+				var currencies []Response
+				if err := c.getCached(context.Background(), pathCurrencies, &currencies); err != nil {
+					t.Fatalf("getCached: %v", err)
+				}
+				if len(currencies) != 5 || currencies[0].ID != 1 || currencies[4].ID != 5 {
+					t.Fatalf("got %+v, want the 5 currencies of all pages", currencies)
+				}
+			}
+			if requests != len(pages) {
+				t.Errorf("server got %d requests, want %d with the second call cached", requests, len(pages))
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package lardiAPI
+
+import (
+	"fmt"
+	"strings"
+)
+
+// phonePlan gives the number of digits of national numbers after a country
+// calling code
+type phonePlan struct {
+	code      string
+	minDigits int
+	maxDigits int
+}
+
+// phonePlans lists the calling codes of the countries most often used on the
+// platform. Codes of other countries are only checked against the E.164
+// length limit.
+var phonePlans = []phonePlan{
+	{"380", 9, 9},  // UA
+	{"48", 9, 9},   // PL
+	{"373", 8, 8},  // MD
+	{"40", 9, 9},   // RO
+	{"370", 8, 8},  // LT
+	{"371", 8, 8},  // LV
+	{"372", 7, 8},  // EE
+	{"375", 9, 9},  // BY
+	{"420", 9, 9},  // CZ
+	{"421", 9, 9},  // SK
+	{"36", 8, 9},   // HU
+	{"49", 6, 13},  // DE
+	{"7", 10, 10},  // KZ, RU
+	{"995", 9, 9},  // GE
+	{"90", 10, 10}, // TR
+}
+
+// checkPhone checks that phone is an international number such as
+// "+380 67 123 45 67". Spaces, dashes, dots and parentheses are allowed
+// between digits.
+func checkPhone(phone string) error {
+	digits, ok := strings.CutPrefix(strings.TrimSpace(phone), "+")
+	if !ok {
+		return fmt.Errorf("phone %q must start with + and the country code", phone)
+	}
+	digits = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, digits)
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return fmt.Errorf("phone %q contains invalid characters", phone)
+		}
+	}
+	if len(digits) < 8 || len(digits) > 15 {
+		return fmt.Errorf("phone %q must have 8 to 15 digits", phone)
+	}
+
+	for _, plan := range phonePlans {
+		national, ok := strings.CutPrefix(digits, plan.code)
+		if !ok {
+			continue
+		}
+		if len(national) < plan.minDigits || len(national) > plan.maxDigits {
+			return fmt.Errorf("phone %q has a wrong number of digits for country code +%s", phone, plan.code)
+		}
+		return nil
+	}
+	return nil
+}
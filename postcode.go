@@ -0,0 +1,106 @@
+package lardiAPI
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PostCode is a post code of a waypoint, checked and formatted according to
+// the country of the waypoint
+type PostCode string
+
+// postCodeFormats gives the canonical format of post codes per country sign:
+// N stands for a digit, other characters are written as is. Post codes of
+// other countries are only checked for plausible characters.
+var postCodeFormats = map[string]string{
+	"UA": "NNNNN",
+	"PL": "NN-NNN",
+	"MD": "MD-NNNN",
+	"RO": "NNNNNN",
+	"LT": "LT-NNNNN",
+	"LV": "LV-NNNN",
+	"EE": "NNNNN",
+	"BY": "NNNNNN",
+	"CZ": "NNN NN",
+	"SK": "NNN NN",
+	"HU": "NNNN",
+	"DE": "NNNNN",
+	"KZ": "NNNNNN",
+	"GE": "NNNN",
+	"TR": "NNNNN",
+}
+
+// maxPostCodeLen limits post codes of countries without a known format
+const maxPostCodeLen = 10
+
+// Normalize formats the post code canonically for the country with the given
+// sign, e.g. "00950" becomes "00-950" in "PL" and "2001" becomes "MD-2001"
+// in "MD". Post codes that do not fit the format of the country are only
+// trimmed and upper-cased.
+func (p PostCode) Normalize(countrySign string) PostCode {
+	code := strings.ToUpper(strings.TrimSpace(string(p)))
+	countrySign = strings.ToUpper(countrySign)
+	format, ok := postCodeFormats[countrySign]
+	if !ok {
+		return PostCode(code)
+	}
+	digits, ok := postCodeDigits(code, countrySign)
+	if !ok || len(digits) != strings.Count(format, "N") {
+		return PostCode(code)
+	}
+	var b strings.Builder
+	for _, r := range format {
+		if r == 'N' {
+			b.WriteByte(digits[0])
+			digits = digits[1:]
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return PostCode(b.String())
+}
+
+// Validate checks the post code against the format of the country with the
+// given sign. Spaces, dashes and the country prefix may be missing or
+// differ, as Normalize fixes them.
+func (p PostCode) Validate(countrySign string) error {
+	code := strings.ToUpper(strings.TrimSpace(string(p)))
+	if code == "" {
+		return errors.New("post code is empty")
+	}
+	countrySign = strings.ToUpper(countrySign)
+	format, ok := postCodeFormats[countrySign]
+	if !ok {
+		if len(code) > maxPostCodeLen || strings.IndexFunc(code, func(r rune) bool {
+			return !(r >= '0' && r <= '9' || r >= 'A' && r <= 'Z' || r == ' ' || r == '-')
+		}) >= 0 {
+			return fmt.Errorf("invalid post code %q", string(p))
+		}
+		return nil
+	}
+	digits, ok := postCodeDigits(code, countrySign)
+	if !ok || len(digits) != strings.Count(format, "N") {
+		return fmt.Errorf("invalid post code %q for %s, expected format %s",
+			string(p), countrySign, strings.ReplaceAll(format, "N", "9"))
+	}
+	return nil
+}
+
+// postCodeDigits returns the digits of an upper-cased post code without an
+// optional country prefix. It reports false if the code contains anything
+// but digits, spaces and dashes after the prefix.
+func postCodeDigits(code, countrySign string) (string, bool) {
+	code = strings.TrimPrefix(code, countrySign)
+	var digits strings.Builder
+	for _, r := range code {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-':
+		default:
+			return "", false
+		}
+	}
+	return digits.String(), true
+}
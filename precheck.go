@@ -0,0 +1,163 @@
+package lardiAPI
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// PrecheckCargo validates req against live reference data before posting:
+// the required fields, the currency, unit, payment moment, payment form,
+// body type, load type and branch IDs, the country signs and the resolution
+// of every waypoint.
+// A payment unit that does not fit the currency and the Warnings of req are
+// logged, or reported as errors with StrictPrecheck; the payment unit error
+// wraps ErrIncompatiblePayment.
+// The checks run concurrently and share cached reference fetches; everything
+// found wrong is returned as a single joined error.
+func (c *Client) PrecheckCargo(ctx context.Context, req *CargoRequest, opts ...RequestOption) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	run := func(check func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := check(); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	run(req.Validate)
+	run(func() error {
+		if c.config.StrictPrecheck {
+			return errors.Join(req.Warnings()...)
+		}
+		c.warnCargo(req)
+		return nil
+	})
+	for _, check := range cargoReferenceChecks(c.withDefaults(req)) {
+		if check.path == pathAreas {
+			// Areas are covered by the waypoint checks below
+			continue
+		}
+		run(func() error {
+			return c.checkReferences(ctx, []referenceCheck{check}, false, opts...)
+		})
+	}
+
+	if req.PaymentUnitID != 0 && req.PaymentCurrencyID != 0 {
+		run(func() error {
+			return c.checkPaymentUnit(ctx, req, opts)
+		})
+	}
+
+	waypoints := map[string][]LoadParams{
+		"waypointListSource": req.WaypointListSource,
+		"waypointListTarget": req.WaypointListTarget,
+	}
+	for list, points := range waypoints {
+		for i, p := range points {
+			run(func() error {
+				if p.CountrySign != "" && !isCountrySign(p.CountrySign) {
+					return fmt.Errorf("%s[%d]: invalid country sign %q", list, i, p.CountrySign)
+				}
+				if _, err := c.ResolveWaypoint(ctx, p, opts...); err != nil {
+					return fmt.Errorf("%s[%d]: %w", list, i, err)
+				}
+				return nil
+			})
+		}
+	}
+
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// ErrIncompatiblePayment is reported by PrecheckCargo for a payment unit
+// that does not fit the payment currency
+var ErrIncompatiblePayment = errors.New("payment unit does not fit currency")
+
+// paymentUnit is a payment unit reference entry. CurrencyIDs, if the API
+// sends it, lists the currencies the unit may be used with.
+type paymentUnit struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	CurrencyIDs []int  `json:"currencyIds"`
+}
+
+// checkPaymentUnit checks the payment unit of req against the currency. When
+// the unit lists its currencies, the currency must be one of them. Otherwise
+// a unit whose name mentions a different currency, e.g. "USD/km" with
+// hryvnias, is considered a mismatch.
+func (c *Client) checkPaymentUnit(ctx context.Context, req *CargoRequest, opts []RequestOption) error {
+	var (
+		units      []paymentUnit
+		currencies []Response
+	)
+	if err := c.getCached(ctx, pathUnits, &units, opts...); err != nil {
+		return err
+	}
+	if err := c.getCached(ctx, pathCurrencies, &currencies, opts...); err != nil {
+		return err
+	}
+	unit := slices.IndexFunc(units, func(u paymentUnit) bool { return u.ID == req.PaymentUnitID })
+	currency := findByID(currencies, req.PaymentCurrencyID)
+	if unit < 0 || currency == nil {
+		// Unknown IDs are reported by the reference checks
+		return nil
+	}
+
+	u := units[unit]
+	fits := slices.Contains(u.CurrencyIDs, currency.ID)
+	if len(u.CurrencyIDs) == 0 {
+		fits = true
+		name := NormalizeName(u.Name)
+		for _, other := range currencies {
+			otherName := NormalizeName(other.Name)
+			if other.ID != currency.ID && len(otherName) >= 3 && strings.Contains(name, otherName) {
+				fits = false
+			}
+		}
+	}
+	if fits {
+		return nil
+	}
+
+	err := fmt.Errorf("payment unit %d %q with currency %d %q: %w",
+		u.ID, u.Name, currency.ID, currency.Name, ErrIncompatiblePayment)
+	if c.config.StrictPrecheck {
+		return err
+	}
+	c.config.Logger.Warn("lardiAPI: payment unit does not fit currency",
+		"unitId", u.ID, "unit", u.Name, "currencyId", currency.ID, "currency", currency.Name)
+	return nil
+}
+
+// warnCargo logs the Warnings of req
+func (c *Client) warnCargo(req *CargoRequest) {
+	for _, w := range req.Warnings() {
+		c.config.Logger.Warn("lardiAPI: suspicious cargo request", "warning", w)
+	}
+}
+
+// isCountrySign reports whether s looks like a two-letter country sign
+func isCountrySign(s string) bool {
+	if len(s) != 2 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
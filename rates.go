@@ -0,0 +1,91 @@
+package lardiAPI
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultRateCacheTTL = 5 * time.Minute
+
+// ErrNoRateProvider is returned by exchange rate helpers when
+// Config.RateProvider is not set
+var ErrNoRateProvider = errors.New("no exchange rate provider configured")
+
+// RateProvider supplies currency exchange rates, e.g. from a bank API.
+// Rates maps currency codes to the amount of that currency per unit of base.
+type RateProvider interface {
+	Rates(ctx context.Context, base string) (map[string]float64, error)
+}
+
+type rateCache struct {
+	mu      sync.Mutex
+	entries map[string]rateEntry
+}
+
+type rateEntry struct {
+	rates     map[string]float64
+	expiresAt time.Time
+}
+
+// GetExchangeRates returns the exchange rates for base from the configured
+// RateProvider, cached for Config.RateCacheTTL
+func (c *Client) GetExchangeRates(ctx context.Context, base string) (map[string]float64, error) {
+	if c.config.RateProvider == nil {
+		return nil, ErrNoRateProvider
+	}
+	base = strings.ToUpper(base)
+
+	c.rates.mu.Lock()
+	e, ok := c.rates.entries[base]
+	c.rates.mu.Unlock()
+	if ok && time.Now().Before(e.expiresAt) {
+		return copyRates(e.rates), nil
+	}
+
+	rates, err := c.config.RateProvider.Rates(ctx, base)
+	if err != nil {
+		return nil, fmt.Errorf("get exchange rates failed: %w", err)
+	}
+	normalized := make(map[string]float64, len(rates))
+	for code, rate := range rates {
+		normalized[strings.ToUpper(code)] = rate
+	}
+
+	c.rates.mu.Lock()
+	if c.rates.entries == nil {
+		c.rates.entries = make(map[string]rateEntry)
+	}
+	c.rates.entries[base] = rateEntry{rates: normalized, expiresAt: time.Now().Add(c.config.RateCacheTTL)}
+	c.rates.mu.Unlock()
+
+	return copyRates(normalized), nil
+}
+
+// ConvertPrice converts amount from one currency to another,
+// e.g. to show a price in EUR
+func (c *Client) ConvertPrice(ctx context.Context, amount float64, from, to string) (float64, error) {
+	if strings.EqualFold(from, to) {
+		return amount, nil
+	}
+	rates, err := c.GetExchangeRates(ctx, from)
+	if err != nil {
+		return 0, err
+	}
+	rate, ok := rates[strings.ToUpper(to)]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("no exchange rate from %s to %s", from, to)
+	}
+	return amount * rate, nil
+}
+
+func copyRates(rates map[string]float64) map[string]float64 {
+	out := make(map[string]float64, len(rates))
+	for k, v := range rates {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,99 @@
+package lardiAPI
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// redactedValue replaces the values of redacted fields
+const redactedValue = "REDACTED"
+
+// DefaultRedactFields are the JSON fields masked in logged bodies unless
+// Config.RedactFields is set: credentials and the personal data of contacts
+var DefaultRedactFields = []string{
+	"apiKey", "token", "password",
+	"contactPhone", "contactFace", "face", "phone", "phones", "email",
+}
+
+// redactBody masks the values of the JSON fields named in fields at any depth
+// of body. Names match case-insensitively. It reports false for bodies that
+// are not JSON, such as multipart uploads, which cannot be searched for the
+// fields.
+func redactBody(body []byte, fields []string) (string, bool) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return "", false
+	}
+	data, err := json.Marshal(redactValue(v, fields))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// loggedBody returns the body as it is logged: redacted JSON, nothing for an
+// empty body and only the size of other bodies
+func loggedBody(body []byte, fields []string) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+	if redacted, ok := redactBody(body, fields); ok {
+		return redacted
+	}
+	return fmt.Sprintf("[%d bytes]", len(body))
+}
+
+func redactValue(v interface{}, fields []string) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if containsFieldName(fields, key) {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactValue(value, fields)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValue(value, fields)
+		}
+	}
+	return v
+}
+
+func containsFieldName(fields []string, name string) bool {
+	for _, f := range fields {
+		if strings.EqualFold(f, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// logExchange logs the bodies of a request and its response at debug level
+// with the fields of Config.RedactFields masked. Headers are not logged, so
+// the API key never reaches the log.
+func (c *Client) logExchange(req *http.Request, status int, respBody []byte) {
+	ctx := req.Context()
+	if !c.config.Logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	var reqBody []byte
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(body)
+			body.Close()
+		}
+	}
+	c.config.Logger.DebugContext(ctx, "lardiAPI: request",
+		"method", req.Method, "path", req.URL.Path, "status", status,
+		"requestBody", loggedBody(reqBody, c.config.RedactFields),
+		"responseBody", loggedBody(respBody, c.config.RedactFields))
+}
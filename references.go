@@ -0,0 +1,421 @@
+package lardiAPI
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// References holds the reference lists needed to build a CargoRequest. Its
+// methods resolve names against the loaded lists without network calls, so
+// that many requests can be built against one snapshot of the reference
+// data. They return errors wrapping ErrReferenceNotFound for unknown names
+// and for lists that failed to load.
+type References struct {
+	Currencies     []Response
+	Units          []Response
+	PaymentMoments []Response
+	PaymentTypes   []Response
+	PaymentForms   []Response
+	BodyTypes      []Response
+	PackageTypes   []Response
+	LoadTypes      []Response
+	// Transliterate makes the lookups also compare names transliterated to
+	// Latin, as Config.TransliterateNames does for the client
+	Transliterate bool
+}
+
+// LoadReferences fetches all reference lists concurrently. If some of them
+// fail, the returned References is still populated with the lists that
+// loaded; the failed fields are nil and the error joins all failures.
+// The returned References is nil only when every fetch failed.
+func (c *Client) LoadReferences(ctx context.Context, opts ...RequestOption) (*References, error) {
+	refs := References{Transliterate: c.config.TransliterateNames}
+	targets := []struct {
+		name string
+		path string
+		dst  *[]Response
+	}{
+		{"currencies", pathCurrencies, &refs.Currencies},
+		{"units", pathUnits, &refs.Units},
+		{"payment moments", pathMoments, &refs.PaymentMoments},
+		{"payment types", pathTypesPayment, &refs.PaymentTypes},
+		{"payment forms", pathPaymentForms, &refs.PaymentForms},
+		{"body types", pathTypes, &refs.BodyTypes},
+		{"package types", pathPackage, &refs.PackageTypes},
+		{"load types", pathLoadTypes, &refs.LoadTypes},
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, t := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var list []Response
+			if err := c.getCached(ctx, t.path, &list, opts...); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("load %s failed: %w", t.name, err))
+				mu.Unlock()
+				return
+			}
+			*t.dst = list
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) == len(targets) {
+		return nil, errors.Join(errs...)
+	}
+	return &refs, errors.Join(errs...)
+}
+
+// VerifyReferenceData fetches every reference list, bypassing the cache, and
+// reports the lists that come back empty, which usually means a wrong
+// Language or missing permissions of the account. It is meant as a smoke
+// test at startup. It is named apart from VerifyReferences, which checks the
+// IDs of a request.
+func (c *Client) VerifyReferenceData(ctx context.Context, opts ...RequestOption) error {
+	lists := []struct {
+		name string
+		path string
+	}{
+		{"currencies", pathCurrencies},
+		{"units", pathUnits},
+		{"payment moments", pathMoments},
+		{"payment types", pathTypesPayment},
+		{"payment forms", pathPaymentForms},
+		{"body types", pathTypes},
+		{"package types", pathPackage},
+		{"load types", pathLoadTypes},
+		{"areas", pathAreas},
+	}
+
+	var errs []error
+	for _, l := range lists {
+		var list []json.RawMessage
+		if err := c.getReference(ctx, l.path, true, &list, opts...); err != nil {
+			errs = append(errs, fmt.Errorf("load %s failed: %w", l.name, err))
+			continue
+		}
+		if len(list) == 0 {
+			errs = append(errs, fmt.Errorf("%s: empty list for language %s", l.name, c.config.Language))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("verify reference data failed: %w", err)
+	}
+	return nil
+}
+
+// Currency returns the ID of the currency with the given name, e.g. "UAH"
+func (r *References) Currency(name string) (int, error) {
+	return findID("currency", r.Currencies, name, r.Transliterate)
+}
+
+// Unit returns the ID of the payment unit with the given name
+func (r *References) Unit(name string) (int, error) {
+	return findID("unit", r.Units, name, r.Transliterate)
+}
+
+// PaymentMoment returns the ID of the payment moment with the given name
+func (r *References) PaymentMoment(name string) (int, error) {
+	return findID("payment moment", r.PaymentMoments, name, r.Transliterate)
+}
+
+// PaymentType returns the ID of the payment type with the given name
+func (r *References) PaymentType(name string) (int, error) {
+	return findID("payment type", r.PaymentTypes, name, r.Transliterate)
+}
+
+// PaymentForm returns the ID of the payment form with the given name
+func (r *References) PaymentForm(name string) (int, error) {
+	return findID("payment form", r.PaymentForms, name, r.Transliterate)
+}
+
+// BodyType returns the ID of the body type with the given name
+func (r *References) BodyType(name string) (int, error) {
+	return findID("body type", r.BodyTypes, name, r.Transliterate)
+}
+
+// PackageType returns the ID of the package type with the given name
+func (r *References) PackageType(name string) (int, error) {
+	return findID("package type", r.PackageTypes, name, r.Transliterate)
+}
+
+// LoadType returns the ID of the load type with the given name
+func (r *References) LoadType(name string) (int, error) {
+	return findID("load type", r.LoadTypes, name, r.Transliterate)
+}
+
+// ResolveCargoNames is like Client.ResolveCargoNames, but resolves the names
+// against the loaded lists without network calls
+func (r *References) ResolveCargoNames(req *CargoRequest, names CargoNames) error {
+	lists := map[string][]Response{
+		pathCurrencies: r.Currencies,
+		pathUnits:      r.Units,
+		pathMoments:    r.PaymentMoments,
+		pathTypes:      r.BodyTypes,
+		pathLoadTypes:  r.LoadTypes,
+	}
+	return resolveCargoNames(req, names, r.Transliterate, func(path string) ([]Response, error) {
+		return lists[path], nil
+	})
+}
+
+func findID(kind string, list []Response, name string, transliterated bool) (int, error) {
+	if v := findByName(list, name, transliterated); v != nil {
+		return v.ID, nil
+	}
+	return 0, fmt.Errorf("%s %q: %w", kind, name, ErrReferenceNotFound)
+}
+
+// FindUnit looks up a payment unit by its name
+func (c *Client) FindUnit(ctx context.Context, name string, opts ...RequestOption) (*Response, error) {
+	units, err := c.GetUnits(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if u := findByName(units, name, c.config.TransliterateNames); u != nil {
+		return u, nil
+	}
+	return nil, fmt.Errorf("unit %q: %w", name, ErrReferenceNotFound)
+}
+
+// FindPaymentForm looks up a payment form by its name
+func (c *Client) FindPaymentForm(ctx context.Context, name string, opts ...RequestOption) (*Response, error) {
+	forms, err := c.GetPaymentForms(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if f := findByName(forms, name, c.config.TransliterateNames); f != nil {
+		return f, nil
+	}
+	return nil, fmt.Errorf("payment form %q: %w", name, ErrReferenceNotFound)
+}
+
+// GetUnitByID looks up a payment unit by its ID
+func (c *Client) GetUnitByID(ctx context.Context, id int, opts ...RequestOption) (*Response, error) {
+	units, err := c.GetUnits(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if u := findByID(units, id); u != nil {
+		return u, nil
+	}
+	return nil, fmt.Errorf("unit %d: %w", id, ErrReferenceNotFound)
+}
+
+// VerifyReferences checks that every reference ID used in req still exists.
+// Reference lists are fetched fresh, bypassing the cache, and all missing IDs
+// are reported together as errors wrapping ErrReferenceNotFound.
+func (c *Client) VerifyReferences(ctx context.Context, req *CargoRequest, opts ...RequestOption) error {
+	if err := c.checkReferences(ctx, cargoReferenceChecks(c.withDefaults(req)), true, opts...); err != nil {
+		return fmt.Errorf("verify references failed: %w", err)
+	}
+	return nil
+}
+
+// ValidateWithClient runs Validate and additionally checks that the body
+// types, currency, unit and other reference IDs of req exist, using cached
+// reference data. All invalid IDs are reported together as errors wrapping
+// ErrReferenceNotFound.
+func (c *Client) ValidateWithClient(
+	ctx context.Context, req *CargoRequest, opts ...RequestOption,
+) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	return c.checkReferences(ctx, cargoReferenceChecks(c.withDefaults(req)), false, opts...)
+}
+
+// referenceCheck lists IDs of a request that must exist in a reference
+type referenceCheck struct {
+	kind string
+	path string
+	ids  []int
+}
+
+func cargoReferenceChecks(req *CargoRequest) []referenceCheck {
+	var areaIDs []int
+	for _, w := range req.WaypointListSource {
+		areaIDs = append(areaIDs, w.AreaID)
+	}
+	for _, w := range req.WaypointListTarget {
+		areaIDs = append(areaIDs, w.AreaID)
+	}
+	var packageIDs []int
+	for _, p := range req.CargoPackaging {
+		packageIDs = append(packageIDs, p.ID)
+	}
+	var formIDs []int
+	for _, f := range req.PaymentForms {
+		formIDs = append(formIDs, f.ID)
+	}
+
+	return []referenceCheck{
+		{"currency", pathCurrencies, []int{req.PaymentCurrencyID}},
+		{"unit", pathUnits, []int{req.PaymentUnitID}},
+		{"payment moment", pathMoments, []int{req.PaymentMomentID}},
+		{"body type", pathTypes, req.CargoBodyTypeIDs},
+		{"package type", pathPackage, packageIDs},
+		{"payment form", pathPaymentForms, formIDs},
+		{"load type", pathLoadTypes, req.LoadTypes},
+		{"area", pathAreas, areaIDs},
+		{"branch", pathBranches, []int{req.BranchID}},
+	}
+}
+
+// knownIDs returns the IDs of the reference at path. Branches are fetched
+// uncached with GetBranches: they belong to the account of the API key used,
+// which the reference cache only tells apart for WithAPIKey calls, not for
+// the keys of Config.KeyProvider.
+func (c *Client) knownIDs(
+	ctx context.Context, path string, refresh bool, opts []RequestOption,
+) (map[int]bool, error) {
+	known := make(map[int]bool)
+	if path == pathBranches {
+		branches, err := c.GetBranches(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range branches {
+			known[b.ID] = true
+		}
+		return known, nil
+	}
+
+	var list []Area
+	if err := c.getReference(ctx, path, refresh, &list, opts...); err != nil {
+		return nil, err
+	}
+	for _, v := range flattenAreas(list) {
+		known[v.ID] = true
+	}
+	return known, nil
+}
+
+// checkReferences fetches the references needed by checks and reports every
+// ID missing from them. Failures to fetch a reference are returned as is.
+func (c *Client) checkReferences(
+	ctx context.Context, checks []referenceCheck, refresh bool, opts ...RequestOption,
+) error {
+	var errs []error
+	for _, check := range checks {
+		ids := nonZero(check.ids)
+		if len(ids) == 0 {
+			continue
+		}
+		known, err := c.knownIDs(ctx, check.path, refresh, opts)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if !known[id] {
+				errs = append(errs, fmt.Errorf("%s %d: %w", check.kind, id, ErrReferenceNotFound))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// CargoNames holds reference names to be resolved into CargoRequest IDs.
+// Empty names are skipped.
+type CargoNames struct {
+	Currency      string
+	Unit          string
+	PaymentMoment string
+	BodyTypes     []string
+	LoadTypes     []string
+}
+
+// ResolveCargoNames fills the reference IDs of req from names. Fields that
+// already hold an explicit ID are left untouched and their references are
+// not fetched, so a request built entirely from known IDs needs no reference
+// lookups and CreateCargo never depends on the reference endpoints.
+func (c *Client) ResolveCargoNames(
+	ctx context.Context, req *CargoRequest, names CargoNames, opts ...RequestOption,
+) error {
+	return resolveCargoNames(req, names, c.config.TransliterateNames, func(path string) ([]Response, error) {
+		var list []Response
+		err := c.getCached(ctx, path, &list, opts...)
+		return list, err
+	})
+}
+
+// resolveCargoNames fills the IDs of req from names, fetching the reference
+// list of an endpoint path with list only when it is needed
+func resolveCargoNames(
+	req *CargoRequest, names CargoNames, transliterated bool, list func(path string) ([]Response, error),
+) error {
+	resolve := func(kind, path, name string, id *int) error {
+		if *id != 0 || name == "" {
+			return nil
+		}
+		refs, err := list(path)
+		if err != nil {
+			return fmt.Errorf("resolve %s failed: %w", kind, err)
+		}
+		v, err := findID(kind, refs, name, transliterated)
+		if err != nil {
+			return err
+		}
+		*id = v
+		return nil
+	}
+	resolveAll := func(kind, path string, names []string, ids *[]int) error {
+		if len(*ids) > 0 || len(names) == 0 {
+			return nil
+		}
+		resolved := make([]int, len(names))
+		for i, name := range names {
+			if err := resolve(kind, path, name, &resolved[i]); err != nil {
+				return err
+			}
+		}
+		*ids = resolved
+		return nil
+	}
+
+	return errors.Join(
+		resolve("currency", pathCurrencies, names.Currency, &req.PaymentCurrencyID),
+		resolve("unit", pathUnits, names.Unit, &req.PaymentUnitID),
+		resolve("payment moment", pathMoments, names.PaymentMoment, &req.PaymentMomentID),
+		resolveAll("body type", pathTypes, names.BodyTypes, &req.CargoBodyTypeIDs),
+		resolveAll("load type", pathLoadTypes, names.LoadTypes, &req.LoadTypes),
+	)
+}
+
+func nonZero(ids []int) []int {
+	var out []int
+	for _, id := range ids {
+		if id != 0 {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func findByName(list []Response, name string, transliterated bool) *Response {
+	for _, v := range list {
+		if namesMatch(v.Name, name, transliterated) {
+			return &Response{ID: v.ID, Name: v.Name}
+		}
+	}
+	return nil
+}
+
+func findByID(list []Response, id int) *Response {
+	for _, v := range list {
+		if v.ID == id {
+			return &Response{ID: v.ID, Name: v.Name}
+		}
+	}
+	return nil
+}
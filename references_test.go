@@ -0,0 +1,41 @@
+package lardiAPI
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateWithClientBranchesPerKey(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case !strings.HasSuffix(r.URL.Path, pathBranches):
+			w.Write([]byte(`[{"id":1,"name":"Тент"}]`))
+		case r.Header.Get("Authorization") == "key-a":
+			// Each account has its own branch
+			w.Write([]byte(`[{"id":10,"name":"Київ"}]`))
+		default:
+			w.Write([]byte(`[{"id":20,"name":"Львів"}]`))
+		}
+	}))
+	defer srv.Close()
+	c := NewClient(Config{BaseURL: srv.URL, KeyProvider: NewRoundRobinKeys("key-a", "key-b")})
+	ctx := context.Background()
+
+	// Cache the body types with key-a, so that each check below sends only
+	// the branches request: the first one with key-b, the second with key-a
+	if _, err := c.GetBodyTypes(ctx, Request{}); err != nil {
+		t.Fatalf("GetBodyTypes: %v", err)
+	}
+	req := testCargoRequest()
+	req.BranchID = 20
+	if err := c.ValidateWithClient(ctx, req); err != nil {
+		t.Errorf("branch 20 with key-b: %v", err)
+	}
+	req.BranchID = 10
+	if err := c.ValidateWithClient(ctx, req); err != nil {
+		t.Errorf("branch 10 with key-a: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package lardiAPI
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// Jitter is the strategy used to randomize retry delays so that many
+// clients retrying the same outage do not hit the API in sync
+type Jitter string
+
+// Jitter strategies. With an exponential delay
+// d = min(RetryWait * 2^attempt, RetryMaxDelay):
+//   - JitterNone waits exactly d
+//   - JitterFull waits a random duration in [0, d)
+//   - JitterEqual waits d/2 plus a random duration in [0, d/2)
+const (
+	JitterNone  Jitter = "none"
+	JitterFull  Jitter = "full"
+	JitterEqual Jitter = "equal"
+)
+
+// RetryPolicy decides whether a request that failed with an API error may be
+// retried. It receives the decoded error, so it can retry on application
+// error codes in APIError.Err or APIError.Message as well as on statuses.
+type RetryPolicy func(err *APIError) bool
+
+// DefaultRetryPolicy retries 429 Too Many Requests and server errors.
+// Custom policies can fall back to it:
+//
+//	func(err *lardiAPI.APIError) bool {
+//		return err.Err == "TRY_AGAIN" || lardiAPI.DefaultRetryPolicy(err)
+//	}
+func DefaultRetryPolicy(err *APIError) bool {
+	return err.Status == http.StatusTooManyRequests || err.Status >= http.StatusInternalServerError
+}
+
+// retryCheckFunc is consulted before retrying a non-idempotent request.
+// It reports whether the previous attempt already took effect.
+type retryCheckFunc func(ctx context.Context) (bool, error)
+
+type retryCheckKey struct{}
+
+func withRetryCheck(ctx context.Context, check retryCheckFunc) context.Context {
+	return context.WithValue(ctx, retryCheckKey{}, check)
+}
+
+// canRetry reports whether a failed request may be sent again. Idempotent
+// requests are always retried; POST requests only when a retry check is
+// attached. applied is true when the check found that the previous attempt
+// already took effect, in which case the request counts as successful.
+func (c *Client) canRetry(req *http.Request) (retry, applied bool, err error) {
+	if req.Method != http.MethodPost {
+		return true, false, nil
+	}
+	check, ok := req.Context().Value(retryCheckKey{}).(retryCheckFunc)
+	if !ok {
+		return false, false, nil
+	}
+	done, err := check(req.Context())
+	if err != nil {
+		return false, false, fmt.Errorf("retry dedupe check failed: %w", err)
+	}
+	return !done, done, nil
+}
+
+// maxRetries returns the number of retries of a request: the WithRetries
+// option, the RetryOverrides entry of its category or MaxRetries
+func (c *Client) maxRetries(req *http.Request, o *requestOptions) int {
+	if o.retries != nil {
+		return *o.retries
+	}
+	if n, ok := c.config.RetryOverrides[category(req)]; ok {
+		return n
+	}
+	return c.config.MaxRetries
+}
+
+// backoff returns the delay before the retry following attempt
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.config.RetryWait << attempt
+	if attempt >= 32 || d <= 0 || d > c.config.RetryMaxDelay {
+		// Plateau at the cap, also guarding against shift overflow
+		d = c.config.RetryMaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	switch c.config.RetryJitter {
+	case JitterNone:
+		return d
+	case JitterEqual:
+		half := d / 2
+		return half + rand.N(d-half)
+	default:
+		return rand.N(d)
+	}
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// findCreatedCargo looks for a recently created cargo of the account that
+// matches req by content, dates and route
+func (c *Client) findCreatedCargo(
+	ctx context.Context, req *CargoRequest, opts ...RequestOption,
+) (*Cargo, error) {
+	cargos, _, err := c.ListMyCargos(ctx, PageInfo{}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range cargos {
+		if sameCargo(&cargos[i], req) {
+			return &cargos[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func sameCargo(cargo *Cargo, req *CargoRequest) bool {
+	return cargo.ContentName == req.ContentName &&
+		cargo.DateFrom == req.DateFrom &&
+		cargo.DateTo == req.DateTo &&
+		sameRoute(cargo.WaypointListSource, req.WaypointListSource) &&
+		sameRoute(cargo.WaypointListTarget, req.WaypointListTarget)
+}
+
+func sameRoute(a, b []LoadParams) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].TownName != b[i].TownName || a[i].CountrySign != b[i].CountrySign {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package lardiAPI
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// CargoFilter selects the cargo proposals of other accounts returned by
+// SearchCargo. Zero fields do not filter.
+type CargoFilter struct {
+	// CountryFrom and CountryTo are country signs of the route, e.g. "UA"
+	CountryFrom string
+	CountryTo   string
+	// AreaFrom and AreaTo are area IDs of the route
+	AreaFrom int
+	AreaTo   int
+	// DateFrom and DateTo limit the loading dates, in the date format of
+	// CargoRequest.DateFrom
+	DateFrom    string
+	DateTo      string
+	BodyTypeIDs []int
+	// BodyTypeMatch tells whether a cargo must accept any or all of
+	// BodyTypeIDs. Empty means BodyTypeAny.
+	BodyTypeMatch BodyTypeMatch
+	MassFrom      float64
+	MassTo        float64
+	// Page is the page to fetch: a zero PageInfo for the first page and the
+	// result of Next() for the following ones
+	Page PageInfo
+}
+
+func (f CargoFilter) query() url.Values {
+	q := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	setInt := func(key string, v int) {
+		if v != 0 {
+			q.Set(key, strconv.Itoa(v))
+		}
+	}
+	setFloat := func(key string, v float64) {
+		if v != 0 {
+			q.Set(key, strconv.FormatFloat(v, 'f', -1, 64))
+		}
+	}
+	set("countryFrom", strings.ToUpper(f.CountryFrom))
+	set("countryTo", strings.ToUpper(f.CountryTo))
+	setInt("areaFrom", f.AreaFrom)
+	setInt("areaTo", f.AreaTo)
+	set("dateFrom", f.DateFrom)
+	set("dateTo", f.DateTo)
+	if len(f.BodyTypeIDs) > 0 {
+		ids := make([]string, len(f.BodyTypeIDs))
+		for i, id := range f.BodyTypeIDs {
+			ids[i] = strconv.Itoa(id)
+		}
+		q.Set("bodyTypeIds", strings.Join(ids, ","))
+	}
+	set("bodyTypeMatch", string(f.BodyTypeMatch))
+	setFloat("massFrom", f.MassFrom)
+	setFloat("massTo", f.MassTo)
+	return q
+}
+
+// SearchCargo retrieves one page of the cargo proposals on the board that
+// match filter, newest first
+func (c *Client) SearchCargo(
+	ctx context.Context, filter CargoFilter, opts ...RequestOption,
+) ([]Cargo, *PageInfo, error) {
+	var cargos []Cargo
+	info, err := c.getPage(ctx, pathSearchCargo, filter.query(), filter.Page, &cargos, opts...)
+	if err != nil && isRecordErrors(err) {
+		return cargos, info, fmt.Errorf("search cargo: %w", err)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("search cargo failed: %w", err)
+	}
+	return cargos, info, nil
+}
@@ -0,0 +1,98 @@
+package lardiAPI
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// SaveTemplate stores a partial cargo request under name for use with
+// CreateFromTemplate. Templates are kept in memory for the client's lifetime.
+func (c *Client) SaveTemplate(name string, tmpl *CargoRequest) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.templates == nil {
+		c.templates = make(map[string]*CargoRequest)
+	}
+	c.templates[name] = MergeCargo(tmpl, nil)
+}
+
+// Template returns a copy of the named template
+func (c *Client) Template(name string) (*CargoRequest, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tmpl, ok := c.templates[name]
+	if !ok {
+		return nil, false
+	}
+	return MergeCargo(tmpl, nil), true
+}
+
+// DeleteTemplate removes the named template
+func (c *Client) DeleteTemplate(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.templates, name)
+}
+
+// CreateFromTemplate merges overrides onto the named template and creates
+// the resulting cargo proposal
+func (c *Client) CreateFromTemplate(
+	ctx context.Context, name string, overrides *CargoRequest, opts ...RequestOption,
+) (*CargoResponse, error) {
+	tmpl, ok := c.Template(name)
+	if !ok {
+		return nil, fmt.Errorf("cargo template %q not found", name)
+	}
+	return c.CreateCargo(ctx, MergeCargo(tmpl, overrides), opts...)
+}
+
+// MergeCargo returns a copy of base with the non-zero fields of overrides
+// applied. Waypoints are merged element by element, so an override may change
+// a single field of one waypoint; extra override waypoints are appended.
+// Other lists are replaced as a whole when the override is non-empty.
+// A false Groupage cannot override a true one.
+func MergeCargo(base, overrides *CargoRequest) *CargoRequest {
+	var merged CargoRequest
+	if base != nil {
+		mergeValue(reflect.ValueOf(&merged).Elem(), reflect.ValueOf(*base))
+	}
+	if overrides != nil {
+		mergeValue(reflect.ValueOf(&merged).Elem(), reflect.ValueOf(*overrides))
+	}
+	return &merged
+}
+
+var loadParamsType = reflect.TypeOf(LoadParams{})
+
+func mergeValue(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			mergeValue(dst.Field(i), src.Field(i))
+		}
+	case reflect.Slice:
+		if src.Len() == 0 {
+			return
+		}
+		if src.Type().Elem() != loadParamsType {
+			dst.Set(reflect.AppendSlice(reflect.MakeSlice(src.Type(), 0, src.Len()), src))
+			return
+		}
+		n := max(dst.Len(), src.Len())
+		merged := reflect.MakeSlice(src.Type(), n, n)
+		for i := 0; i < n; i++ {
+			if i < dst.Len() {
+				mergeValue(merged.Index(i), dst.Index(i))
+			}
+			if i < src.Len() {
+				mergeValue(merged.Index(i), src.Index(i))
+			}
+		}
+		dst.Set(merged)
+	default:
+		if !src.IsZero() {
+			dst.Set(src)
+		}
+	}
+}
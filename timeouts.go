@@ -0,0 +1,36 @@
+package lardiAPI
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Endpoint categories used as keys of Config.TimeoutOverrides and
+// Config.RetryOverrides:
+//   - CategoryReferences: GetCurrencies, GetUnits, GetAreas and the other
+//     reference getters and resolvers
+//   - CategoryCargoRead: ListMyCargos, GetCargoStats and other GET requests
+//     for proposals
+//   - CategoryCargoWrite: CreateCargo, UpdateCargo, DeleteCargo,
+//     AttachCargoFile and other proposal changes
+//   - CategoryAccount: GetContacts, GetAccountInfo
+const (
+	CategoryReferences = "references"
+	CategoryCargoRead  = "cargo-read"
+	CategoryCargoWrite = "cargo-write"
+	CategoryAccount    = "account"
+)
+
+// category returns the endpoint category of the request
+func category(req *http.Request) string {
+	path := req.URL.Path
+	switch {
+	case strings.Contains(path, "/references/"):
+		return CategoryReferences
+	case strings.Contains(path, "/users/"):
+		return CategoryAccount
+	case req.Method == http.MethodGet:
+		return CategoryCargoRead
+	}
+	return CategoryCargoWrite
+}
@@ -0,0 +1,125 @@
+package lardiAPI
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validate is safe for concurrent use and caches struct metadata
+var validate = validator.New()
+
+// ErrInvalidRequest matches the errors of Validate other than the
+// validator.ValidationErrors of missing or malformed fields, e.g. an invalid
+// contact phone or waypoint quantities exceeding the cargo totals
+var ErrInvalidRequest = errors.New("invalid cargo request")
+
+// invalidRequest marks the joined errs as ErrInvalidRequest
+func invalidRequest(errs ...error) error {
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	return nil
+}
+
+// Validate checks that the required fields are set, that the contact phone,
+// if any, is a valid international number and that per-waypoint quantities are
+// consistent with the cargo totals. Waypoint quantities are optional; when
+// given, the quantities loaded at the sources and unloaded at the targets
+// must not exceed SizeMass/SizeVolume, and must add up to them when every
+// waypoint of the list specifies its quantity. Missing or malformed fields
+// are reported as validator.ValidationErrors; the other errors match
+// ErrInvalidRequest.
+func (r *CargoRequest) Validate() error {
+	return r.validate(false)
+}
+
+// validate checks r. A draft only requires the waypoints; its quantities are
+// checked against the cargo totals once the totals are set.
+func (r *CargoRequest) validate(draft bool) error {
+	var err error
+	if draft {
+		err = validate.StructPartial(r, "WaypointListSource", "WaypointListTarget")
+	} else {
+		err = validate.Struct(r)
+	}
+	if err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return fmt.Errorf("unexpected validation error: %w", err)
+		}
+		return validationErrors
+	}
+	var phone error
+	if r.ContactPhone != "" {
+		phone = checkPhone(r.ContactPhone)
+	}
+	if draft && r.SizeMass == 0 && r.SizeVolume == 0 {
+		return invalidRequest(phone)
+	}
+
+	return invalidRequest(
+		phone,
+		checkWaypointTotals("waypointListSource", "sizeMass", r.WaypointListSource, r.SizeMass,
+			func(p LoadParams) float64 { return p.SizeMass }),
+		checkWaypointTotals("waypointListTarget", "sizeMass", r.WaypointListTarget, r.SizeMass,
+			func(p LoadParams) float64 { return p.SizeMass }),
+		checkWaypointTotals("waypointListSource", "sizeVolume", r.WaypointListSource, r.SizeVolume,
+			func(p LoadParams) float64 { return p.SizeVolume }),
+		checkWaypointTotals("waypointListTarget", "sizeVolume", r.WaypointListTarget, r.SizeVolume,
+			func(p LoadParams) float64 { return p.SizeVolume }),
+	)
+}
+
+func checkWaypointTotals(
+	list, field string, waypoints []LoadParams, total float64, value func(LoadParams) float64,
+) error {
+	var sum float64
+	specified := 0
+	for _, w := range waypoints {
+		v := value(w)
+		if v < 0 {
+			return fmt.Errorf("%s: %s must not be negative", list, field)
+		}
+		if v > 0 {
+			sum += v
+			specified++
+		}
+	}
+	if specified == 0 {
+		return nil
+	}
+
+	const epsilon = 1e-9
+	if sum > total+epsilon {
+		return fmt.Errorf("%s: total %s %g exceeds cargo %s %g", list, field, sum, field, total)
+	}
+	if specified == len(waypoints) && sum < total-epsilon {
+		return fmt.Errorf("%s: total %s %g does not match cargo %s %g", list, field, sum, field, total)
+	}
+	return nil
+}
+
+// ErrGroupageLorries is the warning for a groupage cargo that needs more
+// than one lorry, which contradicts sharing a lorry with other cargo
+var ErrGroupageLorries = errors.New("groupage cargo needs several lorries")
+
+// SetGroupage marks the cargo as a partial load that shares a lorry with
+// other cargo, or as a full load. LorryAmount is left as is; a partial load
+// needing several lorries is reported by Warnings.
+func (r *CargoRequest) SetGroupage(partial bool) {
+	r.Groupage = partial
+}
+
+// Warnings reports likely mistakes in r that the API accepts, so unlike the
+// errors of Validate they do not prevent posting the cargo. A groupage cargo
+// with LorryAmount above 1 yields an error wrapping ErrGroupageLorries.
+func (r *CargoRequest) Warnings() []error {
+	var warnings []error
+	if r.Groupage && r.LorryAmount > 1 {
+		warnings = append(warnings,
+			fmt.Errorf("groupage: a partial load needs %d lorries: %w", r.LorryAmount, ErrGroupageLorries))
+	}
+	return warnings
+}
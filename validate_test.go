@@ -0,0 +1,69 @@
+package lardiAPI
+
+import (
+	"errors"
+	"testing"
+)
+
+// testCargoRequest returns a request that passes Validate
+func testCargoRequest() *CargoRequest {
+	return &CargoRequest{
+		DateFrom:           "2024-11-10",
+		CargoBodyTypeIDs:   []int{1},
+		ContentName:        "Electronics",
+		SizeMass:           10,
+		WaypointListSource: []LoadParams{{TownName: "Київ", CountrySign: "UA"}},
+		WaypointListTarget: []LoadParams{{TownName: "Львів", CountrySign: "UA"}},
+	}
+}
+
+func TestValidateInvalidRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *CargoRequest)
+	}{
+		{"bad phone", func(r *CargoRequest) { r.ContactPhone = "067 123" }},
+		{"waypoint mass exceeds total", func(r *CargoRequest) {
+			r.WaypointListSource[0].SizeMass = 20
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testCargoRequest()
+			tt.modify(r)
+			err := r.Validate()
+			if !errors.Is(err, ErrInvalidRequest) {
+				t.Fatalf("Validate() = %v, want ErrInvalidRequest", err)
+			}
+			if got := HTTPStatus(err); got != 400 {
+				t.Errorf("HTTPStatus() = %d, want 400", got)
+			}
+		})
+	}
+
+	if err := testCargoRequest().Validate(); err != nil {
+		t.Fatalf("Validate() of a valid request = %v", err)
+	}
+}
+
+func TestGroupageLorriesIsWarning(t *testing.T) {
+	req := testCargoRequest()
+	req.LorryAmount = 3
+	req.SetGroupage(true)
+
+	if req.LorryAmount != 3 {
+		t.Errorf("SetGroupage changed LorryAmount to %d", req.LorryAmount)
+	}
+	if err := req.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+	warnings := req.Warnings()
+	if len(warnings) != 1 || !errors.Is(warnings[0], ErrGroupageLorries) {
+		t.Errorf("Warnings() = %v, want ErrGroupageLorries", warnings)
+	}
+
+	req.LorryAmount = 1
+	if warnings := req.Warnings(); len(warnings) != 0 {
+		t.Errorf("Warnings() with one lorry = %v, want none", warnings)
+	}
+}
@@ -0,0 +1,144 @@
+package lardiAPI
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// maxWatchBackoff limits how much polling slows down after repeated errors
+const maxWatchBackoff = 8
+
+// maxSeenIDs bounds the IDs a watcher remembers. Cargos drop off the first
+// page of a search, and responses off a proposal, long before that many newer
+// ones are seen.
+const maxSeenIDs = 10000
+
+// WatchCargoResponses polls the carrier responses to a cargo proposal every
+// interval and calls fn once for each new response, with the contact details
+// returned by GetCargoResponses. It runs until ctx is cancelled or fn returns
+// an error, and returns that error. The IDs of the last 10000 responses are
+// remembered. Failed polls are logged and retried with a growing delay of up
+// to 8 intervals, except for permanent errors such as a deleted proposal
+// (ErrNotFound) or a rejected API key (ErrUnauthorized), which are returned.
+// interval must be positive.
+func (c *Client) WatchCargoResponses(
+	ctx context.Context, id int, interval time.Duration,
+	fn func(CargoContactResponse) error, opts ...RequestOption,
+) error {
+	if interval <= 0 {
+		return fmt.Errorf("watch cargo responses: interval must be positive, got %v", interval)
+	}
+	seen := newSeenSet(maxSeenIDs)
+	delay := interval
+	for {
+		responses, err := c.GetCargoResponses(ctx, id, opts...)
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case isPermanent(err):
+			return err
+		case err != nil:
+			c.config.Logger.Warn("lardiAPI: polling cargo responses failed",
+				"cargoId", id, "error", err)
+			delay = min(delay*2, interval*maxWatchBackoff)
+		default:
+			delay = interval
+			for _, r := range responses {
+				if !seen.add(r.ID) {
+					continue
+				}
+				if err := fn(r); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// WatchNewCargo polls the first page of SearchCargo with filter every
+// interval and calls fn once for each cargo not seen before, including the
+// ones found by the first poll. It runs until ctx is cancelled or fn returns
+// an error, and returns that error. The IDs of the last 10000 cargos are
+// remembered, so memory stays bounded on long runs. Failed polls are logged
+// and retried, and permanent errors returned, as in WatchCargoResponses.
+// interval must be positive.
+func (c *Client) WatchNewCargo(
+	ctx context.Context, filter CargoFilter, interval time.Duration,
+	fn func(Cargo) error, opts ...RequestOption,
+) error {
+	if interval <= 0 {
+		return fmt.Errorf("watch new cargo: interval must be positive, got %v", interval)
+	}
+	filter.Page = PageInfo{Size: filter.Page.Size}
+	seen := newSeenSet(maxSeenIDs)
+	delay := interval
+	for {
+		cargos, _, err := c.SearchCargo(ctx, filter, opts...)
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case isPermanent(err):
+			return err
+		case err != nil:
+			c.config.Logger.Warn("lardiAPI: polling new cargo failed", "error", err)
+			delay = min(delay*2, interval*maxWatchBackoff)
+		default:
+			delay = interval
+			for _, cargo := range cargos {
+				if !seen.add(cargo.ID) {
+					continue
+				}
+				if err := fn(cargo); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// isPermanent reports whether a failed poll would fail again the same way:
+// the watched resource is gone or the API key is not accepted
+func isPermanent(err error) bool {
+	var apiErr *APIError
+	return errors.Is(err, ErrNotFound) || errors.Is(err, ErrUnauthorized) ||
+		errors.As(err, &apiErr) && apiErr.Status == http.StatusForbidden
+}
+
+// seenSet remembers the most recently added IDs up to a limit, forgetting the
+// least recently seen ones first
+type seenSet struct {
+	limit int
+	order *list.List
+	ids   map[int]*list.Element
+}
+
+func newSeenSet(limit int) *seenSet {
+	return &seenSet{limit: limit, order: list.New(), ids: make(map[int]*list.Element)}
+}
+
+// add records id and reports whether it was new
+func (s *seenSet) add(id int) bool {
+	if e, ok := s.ids[id]; ok {
+		s.order.MoveToFront(e)
+		return false
+	}
+	s.ids[id] = s.order.PushFront(id)
+	if s.order.Len() > s.limit {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.ids, oldest.Value.(int))
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package lardiAPI
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatchRejectsNonPositiveInterval(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+	c := NewClient(Config{BaseURL: srv.URL, APIKey: "key"})
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		err := c.WatchCargoResponses(context.Background(), 1, interval,
+			func(CargoContactResponse) error { return nil })
+		if err == nil {
+			t.Errorf("WatchCargoResponses(interval=%v) = nil, want error", interval)
+		}
+		err = c.WatchNewCargo(context.Background(), CargoFilter{}, interval,
+			func(Cargo) error { return nil })
+		if err == nil {
+			t.Errorf("WatchNewCargo(interval=%v) = nil, want error", interval)
+		}
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("sent %d requests, want 0", n)
+	}
+}
+
+func TestWatchStopsOnPermanentErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusUnauthorized, ErrUnauthorized},
+	}
+	for _, tt := range tests {
+		var requests atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(tt.status)
+			w.Write([]byte(`{"message":"gone"}`))
+		}))
+		c := NewClient(Config{BaseURL: srv.URL, APIKey: "key"})
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+
+		err := c.WatchCargoResponses(ctx, 1, time.Millisecond,
+			func(CargoContactResponse) error { return nil }, WithRetries(0))
+		if !errors.Is(err, tt.want) {
+			t.Errorf("WatchCargoResponses on %d = %v, want %v", tt.status, err, tt.want)
+		}
+		err = c.WatchNewCargo(ctx, CargoFilter{}, time.Millisecond,
+			func(Cargo) error { return nil }, WithRetries(0))
+		if !errors.Is(err, tt.want) {
+			t.Errorf("WatchNewCargo on %d = %v, want %v", tt.status, err, tt.want)
+		}
+		if n := requests.Load(); n != 2 {
+			t.Errorf("status %d: sent %d requests, want one per watcher", tt.status, n)
+		}
+		cancel()
+		srv.Close()
+	}
+}
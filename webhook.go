@@ -0,0 +1,73 @@
+package lardiAPI
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Webhook events
+const (
+	EventCargoResponse      = "cargo.response"
+	EventCargoStatusChanged = "cargo.status_changed"
+	EventCargoExpired       = "cargo.expired"
+)
+
+var webhookEvents = map[string]bool{
+	EventCargoResponse:      true,
+	EventCargoStatusChanged: true,
+	EventCargoExpired:       true,
+}
+
+// Webhook is a registered notification endpoint
+type Webhook struct {
+	ID     int      `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// RegisterWebhook registers url to be notified about events, e.g.
+// EventCargoResponse. The URL must be an absolute https URL.
+func (c *Client) RegisterWebhook(
+	ctx context.Context, rawURL string, events []string, opts ...RequestOption,
+) (*Webhook, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("invalid webhook URL %q: must be an absolute https URL", rawURL)
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("webhook requires at least one event")
+	}
+	for _, e := range events {
+		if !webhookEvents[e] {
+			return nil, fmt.Errorf("unknown webhook event %q", e)
+		}
+	}
+
+	var resp Webhook
+	err = c.post(ctx, pathWebhooks, Webhook{URL: rawURL, Events: events}, &resp, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("register webhook failed: %w", err)
+	}
+	return &resp, nil
+}
+
+// ListWebhooks retrieves the registered webhooks
+func (c *Client) ListWebhooks(ctx context.Context, opts ...RequestOption) ([]Webhook, error) {
+	var resp []Webhook
+	err := c.get(ctx, pathWebhooks, &resp, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("list webhooks failed: %w", err)
+	}
+	return resp, nil
+}
+
+// DeleteWebhook removes a webhook. The error matches ErrNotFound if the
+// webhook does not exist.
+func (c *Client) DeleteWebhook(ctx context.Context, id int, opts ...RequestOption) error {
+	err := c.delete(ctx, fmt.Sprintf(pathWebhook, id), nil, opts...)
+	if err != nil {
+		return fmt.Errorf("delete webhook failed: %w", err)
+	}
+	return nil
+}